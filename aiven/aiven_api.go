@@ -0,0 +1,107 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+// aivenAPIURL is the Aiven API used for endpoints that aiven-go-client v1.6.1 does not
+// cover yet, AIVEN_WEB_URL overrides it the same way as it does for the client
+var aivenAPIURL = func() string {
+	if v, ok := os.LookupEnv("AIVEN_WEB_URL"); ok {
+		return v + "/v1"
+	}
+
+	return "https://api.aiven.io/v1"
+}()
+
+// aivenAPIPath builds an API path out of path escaped parts
+func aivenAPIPath(parts ...string) string {
+	escaped := make([]string, len(parts))
+	for i, p := range parts {
+		escaped[i] = url.PathEscape(p)
+	}
+
+	return "/" + strings.Join(escaped, "/")
+}
+
+// aivenAPIRequest sends a JSON request with the credentials of the client and decodes
+// the response into out; failures are returned as aiven.Error so that aiven.IsNotFound
+// and friends work on them, GET requests are retried on server errors like the client does
+func aivenAPIRequest(ctx context.Context, client *aiven.Client, method, path string, in, out interface{}) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+
+	httpClient := client.Client
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	for retries := 2; ; retries-- {
+		req, err := http.NewRequestWithContext(ctx, method, aivenAPIURL+path, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", client.UserAgent)
+		req.Header.Set("Authorization", "aivenv1 "+client.APIKey)
+
+		rsp, err := httpClient.Do(req)
+		if err != nil {
+			return err
+		}
+
+		b, err := ioutil.ReadAll(rsp.Body)
+		_ = rsp.Body.Close()
+		if err != nil {
+			return err
+		}
+
+		if (rsp.StatusCode == 408 || rsp.StatusCode >= 500) && method == http.MethodGet && retries > 0 {
+			continue
+		}
+
+		if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+			return aiven.Error{Message: string(b), Status: rsp.StatusCode}
+		}
+
+		if out == nil || len(b) == 0 {
+			return nil
+		}
+
+		if err := json.Unmarshal(b, out); err != nil {
+			return fmt.Errorf("cannot unmarshal JSON `%s`, error: %w", b, err)
+		}
+
+		return nil
+	}
+}
+
+// aivenAPICaller returns the email of the user that the API token of the client belongs to
+func aivenAPICaller(ctx context.Context, client *aiven.Client) (string, error) {
+	var r struct {
+		User struct {
+			Email string `json:"user"`
+		} `json:"user"`
+	}
+	if err := aivenAPIRequest(ctx, client, http.MethodGet, aivenAPIPath("me"), nil, &r); err != nil {
+		return "", fmt.Errorf("cannot get the user of the API token: %w", err)
+	}
+
+	return r.User.Email, nil
+}
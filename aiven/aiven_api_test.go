@@ -0,0 +1,63 @@
+package aiven
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+// newTestAivenAPI points aivenAPIRequest to a fake Aiven API for the duration of the test,
+// the returned provider meta has a client of the fake API
+func newTestAivenAPI(t *testing.T, handler http.Handler) *providerMeta {
+	srv := httptest.NewServer(handler)
+	orig := aivenAPIURL
+	aivenAPIURL = srv.URL
+	t.Cleanup(func() {
+		aivenAPIURL = orig
+		srv.Close()
+	})
+
+	return &providerMeta{client: &aiven.Client{APIKey: "token", Client: srv.Client(), UserAgent: "test"}}
+}
+
+func Test_aivenAPIRequest(t *testing.T) {
+	calls := 0
+	meta := newTestAivenAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Authorization") != "aivenv1 token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.EscapedPath() {
+		case "/project/my%2Fproject/flaky":
+			if calls == 1 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, _ = w.Write([]byte(`{"value": "ok"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found"}`))
+		}
+	}))
+
+	var out struct {
+		Value string `json:"value"`
+	}
+	err := aivenAPIRequest(context.Background(), meta.client, http.MethodGet, aivenAPIPath("project", "my/project", "flaky"), nil, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Value != "ok" || calls != 2 {
+		t.Errorf("expected the request to be retried once, got %q after %d calls", out.Value, calls)
+	}
+
+	err = aivenAPIRequest(context.Background(), meta.client, http.MethodGet, aivenAPIPath("project", "missing"), nil, nil)
+	if !aiven.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package aiven
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceAccountProjects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceAccountProjectsRead,
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:        schema.TypeString,
+				Description: "Account id",
+				Required:    true,
+			},
+			"projects": {
+				Type:        schema.TypeList,
+				Description: "Projects of the account",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_name": {
+							Type:        schema.TypeString,
+							Description: "Project name",
+							Computed:    true,
+						},
+						"billing_group_id": {
+							Type:        schema.TypeString,
+							Description: "Billing group id",
+							Computed:    true,
+						},
+						"billing_group_name": {
+							Type:        schema.TypeString,
+							Description: "Billing group name",
+							Computed:    true,
+						},
+						"team_grants": {
+							Type:        schema.TypeList,
+							Description: "Account teams that have access to the project",
+							Computed:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"team_id": {
+										Type:        schema.TypeString,
+										Description: "Account team id",
+										Computed:    true,
+									},
+									"team_name": {
+										Type:        schema.TypeString,
+										Description: "Account team name",
+										Computed:    true,
+									},
+									"team_type": {
+										Type:        schema.TypeString,
+										Description: "Account team project type",
+										Computed:    true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceAccountProjectsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	accountId := d.Get("account_id").(string)
+
+	teams, err := client.AccountTeams.List(accountId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	grants := make(map[string][]map[string]interface{})
+	for _, team := range teams.Teams {
+		r, err := client.AccountTeamProjects.List(accountId, team.Id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		for _, p := range r.Projects {
+			grants[p.ProjectName] = append(grants[p.ProjectName], map[string]interface{}{
+				"team_id":   team.Id,
+				"team_name": team.Name,
+				"team_type": p.TeamType,
+			})
+		}
+	}
+
+	projects, err := client.Projects.List()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var list []map[string]interface{}
+	for _, p := range projects {
+		if p.AccountId != accountId {
+			continue
+		}
+
+		list = append(list, map[string]interface{}{
+			"project_name":       p.Name,
+			"billing_group_id":   p.BillingGroupId,
+			"billing_group_name": p.BillingGroupName,
+			"team_grants":        grants[p.Name],
+		})
+	}
+
+	d.SetId(accountId)
+	if err := d.Set("projects", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
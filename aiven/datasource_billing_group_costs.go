@@ -0,0 +1,170 @@
+package aiven
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceBillingGroupCosts() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceBillingGroupCostsRead,
+		Schema: map[string]*schema.Schema{
+			"billing_group_id": {
+				Type:        schema.TypeString,
+				Description: "Billing group id",
+				Required:    true,
+			},
+			"billing_currency": {
+				Type:        schema.TypeString,
+				Description: "Billing currency of the billing group, project amounts are not converted to it",
+				Computed:    true,
+			},
+			"projects": {
+				Type:        schema.TypeList,
+				Description: "Projects assigned to the billing group",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_name": {
+							Type:        schema.TypeString,
+							Description: "Project name",
+							Computed:    true,
+						},
+						"estimated_balance": {
+							Type:        schema.TypeString,
+							Description: "Current month estimated balance of the project",
+							Computed:    true,
+						},
+						"available_credits": {
+							Type:        schema.TypeString,
+							Description: "Available credits of the project",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"services": {
+				Type:        schema.TypeList,
+				Description: "Current month estimated cost of each service of the billing group",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_name": {
+							Type:        schema.TypeString,
+							Description: "Project name",
+							Computed:    true,
+						},
+						"service_name": {
+							Type:        schema.TypeString,
+							Description: "Service name",
+							Computed:    true,
+						},
+						"service_type": {
+							Type:        schema.TypeString,
+							Description: "Service type",
+							Computed:    true,
+						},
+						"estimated_cost": {
+							Type:        schema.TypeString,
+							Description: "Current month estimated cost of the service",
+							Computed:    true,
+						},
+						"currency": {
+							Type:        schema.TypeString,
+							Description: "Currency of the estimated cost",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceBillingGroupCostsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	id := d.Get("billing_group_id").(string)
+
+	var bg aiven.BillingGroupResponse
+	err := aivenAPIRequest(ctx, client, http.MethodGet, aivenAPIPath("billing-group", id), nil, &bg)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// BillingGroup.GetProjects of aiven-go-client v1.6.1 drops the amounts of the projects
+	var r aiven.BillingGroupProjectsResponse
+	err = aivenAPIRequest(ctx, client, http.MethodGet, aivenAPIPath("billing-group", id, "projects"), nil, &r)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// the amounts are reported per project as the API returns them, they are not summed
+	// up because they are not necessarily in the billing currency of the group
+	var projects []map[string]interface{}
+	for _, p := range r.Projects {
+		projects = append(projects, map[string]interface{}{
+			"project_name":      p.ProjectName,
+			"estimated_balance": p.EstimatedBalance,
+			"available_credits": p.AvailableCredits,
+		})
+	}
+
+	services, err := billingGroupServiceCosts(ctx, client, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	if err := d.Set("billing_currency", bg.BillingGroup.BillingCurrency); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("projects", projects); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("services", services); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// billingGroupServiceCosts returns the service lines of the estimate invoice of the current
+// month, there is none before the first charges of the month
+func billingGroupServiceCosts(ctx context.Context, client *aiven.Client, id string) ([]map[string]interface{}, error) {
+	invoices, err := listBillingGroupInvoices(ctx, client, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []map[string]interface{}
+	for _, i := range invoices {
+		if i.State != "estimate" {
+			continue
+		}
+
+		lines, err := listBillingGroupInvoiceLines(ctx, client, id, i.InvoiceNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, l := range lines {
+			if l.ServiceName == "" {
+				continue
+			}
+
+			services = append(services, map[string]interface{}{
+				"project_name":   l.ProjectName,
+				"service_name":   l.ServiceName,
+				"service_type":   l.ServiceType,
+				"estimated_cost": l.LineTotalLocal,
+				"currency":       l.LocalCurrency,
+			})
+		}
+	}
+
+	return services, nil
+}
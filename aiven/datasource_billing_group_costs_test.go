@@ -0,0 +1,111 @@
+package aiven
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// fakeBillingGroupAPI serves the billing group endpoints of the Aiven API with fixed responses
+var fakeBillingGroupAPI = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	var body string
+	switch r.URL.Path {
+	case "/billing-group/bg1":
+		body = `{"billing_group": {"billing_group_id": "bg1", "billing_currency": "EUR"}}`
+	case "/billing-group/bg1/projects":
+		body = `{"projects": [
+			{"project_name": "p1", "estimated_balance": "12.50", "available_credits": "100.00"}]}`
+	case "/billing-group/bg1/invoice":
+		body = `{"invoices": [
+			{"invoice_number": "inv1", "period_begin": "2021-09-01T00:00:00Z", "period_end": "2021-09-30T23:59:59Z",
+			 "state": "paid", "total_inc_vat": "24.00", "total_vat_zero": "20.00", "currency": "EUR",
+			 "download_cookie": "cookie1"},
+			{"invoice_number": "inv2", "period_begin": "2021-10-01T00:00:00Z", "period_end": "2021-10-31T23:59:59Z",
+			 "state": "estimate", "total_inc_vat": "15.00", "total_vat_zero": "12.50", "currency": "EUR"}]}`
+	case "/billing-group/bg1/invoice/inv2/lines":
+		body = `{"lines": [
+			{"line_type": "service_charge", "project_name": "p1", "service_name": "pg1", "service_type": "pg",
+			 "line_total_local": "10.00", "local_currency": "EUR"},
+			{"line_type": "extra_charge", "project_name": "p1", "line_total_local": "2.50", "local_currency": "EUR"}]}`
+	case "/billing-group/bg1/credits":
+		body = `{"credits": [
+			{"code": "c1", "type": "discount", "value": "200.00", "remaining_value": "100.00",
+			 "start_time": "2021-09-01T00:00:00Z", "expire_time": "2022-09-01T00:00:00Z"}]}`
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write([]byte(body))
+})
+
+func TestBillingGroupCostsRead(t *testing.T) {
+	meta := newTestAivenAPI(t, fakeBillingGroupAPI)
+
+	d := schema.TestResourceDataRaw(t, datasourceBillingGroupCosts().Schema, map[string]interface{}{
+		"billing_group_id": "bg1",
+	})
+	if di := datasourceBillingGroupCostsRead(context.Background(), d, meta); di.HasError() {
+		t.Fatal(di)
+	}
+
+	if d.Get("billing_currency") != "EUR" || d.Get("projects.0.estimated_balance") != "12.50" {
+		t.Errorf("unexpected costs %v %v", d.Get("billing_currency"), d.Get("projects"))
+	}
+
+	want := []interface{}{map[string]interface{}{
+		"project_name":   "p1",
+		"service_name":   "pg1",
+		"service_type":   "pg",
+		"estimated_cost": "10.00",
+		"currency":       "EUR",
+	}}
+	if got := d.Get("services"); !reflect.DeepEqual(got, want) {
+		t.Errorf("services = %v, want %v", got, want)
+	}
+}
+
+func TestBillingGroupInvoicesRead(t *testing.T) {
+	meta := newTestAivenAPI(t, fakeBillingGroupAPI)
+
+	d := schema.TestResourceDataRaw(t, datasourceBillingGroupInvoices().Schema, map[string]interface{}{
+		"billing_group_id": "bg1",
+	})
+	if di := datasourceBillingGroupInvoicesRead(context.Background(), d, meta); di.HasError() {
+		t.Fatal(di)
+	}
+
+	if d.Get("invoices.#") != 2 || d.Get("invoices.0.total_inc_vat") != "24.00" || d.Get("invoices.1.state") != "estimate" {
+		t.Errorf("unexpected invoices %v", d.Get("invoices"))
+	}
+	if got, want := d.Get("invoices.0.download_url"), aivenAPIURL+"/billing-group/bg1/invoice/inv1/cookie1"; got != want {
+		t.Errorf("download_url = %v, want %v", got, want)
+	}
+	if got := d.Get("invoices.1.download_url"); got != "" {
+		t.Errorf("expected no download URL for the estimate, got %v", got)
+	}
+}
+
+func TestBillingGroupCreditsRead(t *testing.T) {
+	meta := newTestAivenAPI(t, fakeBillingGroupAPI)
+
+	d := schema.TestResourceDataRaw(t, datasourceBillingGroupCredits().Schema, map[string]interface{}{
+		"billing_group_id": "bg1",
+	})
+	if di := datasourceBillingGroupCreditsRead(context.Background(), d, meta); di.HasError() {
+		t.Fatal(di)
+	}
+
+	if d.Get("credits.#") != 1 || d.Get("credits.0.code") != "c1" || d.Get("credits.0.remaining_value") != "100.00" {
+		t.Errorf("unexpected credits %v", d.Get("credits"))
+	}
+
+	d = schema.TestResourceDataRaw(t, datasourceBillingGroupCredits().Schema, map[string]interface{}{
+		"billing_group_id": "missing",
+	})
+	if di := datasourceBillingGroupCreditsRead(context.Background(), d, meta); !di.HasError() {
+		t.Error("expected an error for a missing billing group")
+	}
+}
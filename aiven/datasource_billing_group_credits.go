@@ -0,0 +1,107 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// billingGroupCredit is a credit entry of a billing group, aiven-go-client v1.6.1 has no
+// credit endpoints
+type billingGroupCredit struct {
+	Code           string `json:"code"`
+	Type           string `json:"type"`
+	Value          string `json:"value"`
+	RemainingValue string `json:"remaining_value"`
+	StartTime      string `json:"start_time"`
+	ExpireTime     string `json:"expire_time"`
+}
+
+type billingGroupCreditListResponse struct {
+	Credits []billingGroupCredit `json:"credits"`
+}
+
+func datasourceBillingGroupCredits() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceBillingGroupCreditsRead,
+		Schema: map[string]*schema.Schema{
+			"billing_group_id": {
+				Type:        schema.TypeString,
+				Description: "Billing group id",
+				Required:    true,
+			},
+			"credits": {
+				Type:        schema.TypeList,
+				Description: "Credit entries of the billing group",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"code": {
+							Type:        schema.TypeString,
+							Description: "Credit code",
+							Computed:    true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Description: "Credit type",
+							Computed:    true,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Description: "Original value of the credit",
+							Computed:    true,
+						},
+						"remaining_value": {
+							Type:        schema.TypeString,
+							Description: "Remaining value of the credit",
+							Computed:    true,
+						},
+						"start_time": {
+							Type:        schema.TypeString,
+							Description: "Time the credit became available",
+							Computed:    true,
+						},
+						"expire_time": {
+							Type:        schema.TypeString,
+							Description: "Time the credit expires",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceBillingGroupCreditsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	id := d.Get("billing_group_id").(string)
+
+	var r billingGroupCreditListResponse
+	err := aivenAPIRequest(ctx, client, http.MethodGet, aivenAPIPath("billing-group", id, "credits"), nil, &r)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var list []map[string]interface{}
+	for _, c := range r.Credits {
+		list = append(list, map[string]interface{}{
+			"code":            c.Code,
+			"type":            c.Type,
+			"value":           c.Value,
+			"remaining_value": c.RemainingValue,
+			"start_time":      c.StartTime,
+			"expire_time":     c.ExpireTime,
+		})
+	}
+
+	d.SetId(id)
+	if err := d.Set("credits", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// billingGroupInvoice is an invoice of a billing group, aiven-go-client v1.6.1 has no
+// invoice endpoints
+type billingGroupInvoice struct {
+	InvoiceNumber  string `json:"invoice_number"`
+	PeriodBegin    string `json:"period_begin"`
+	PeriodEnd      string `json:"period_end"`
+	State          string `json:"state"`
+	TotalIncVAT    string `json:"total_inc_vat"`
+	TotalVATZero   string `json:"total_vat_zero"`
+	Currency       string `json:"currency"`
+	DownloadCookie string `json:"download_cookie"`
+}
+
+type billingGroupInvoiceListResponse struct {
+	Invoices []billingGroupInvoice `json:"invoices"`
+}
+
+// billingGroupInvoiceLine is a line of a billing group invoice
+type billingGroupInvoiceLine struct {
+	LineType       string `json:"line_type"`
+	ProjectName    string `json:"project_name"`
+	ServiceName    string `json:"service_name"`
+	ServiceType    string `json:"service_type"`
+	LineTotalLocal string `json:"line_total_local"`
+	LocalCurrency  string `json:"local_currency"`
+}
+
+type billingGroupInvoiceLinesResponse struct {
+	Lines []billingGroupInvoiceLine `json:"lines"`
+}
+
+func listBillingGroupInvoices(ctx context.Context, client *aiven.Client, id string) ([]billingGroupInvoice, error) {
+	var r billingGroupInvoiceListResponse
+	err := aivenAPIRequest(ctx, client, http.MethodGet, aivenAPIPath("billing-group", id, "invoice"), nil, &r)
+
+	return r.Invoices, err
+}
+
+func listBillingGroupInvoiceLines(ctx context.Context, client *aiven.Client, id, invoiceNumber string) ([]billingGroupInvoiceLine, error) {
+	var r billingGroupInvoiceLinesResponse
+	path := aivenAPIPath("billing-group", id, "invoice", invoiceNumber, "lines")
+	err := aivenAPIRequest(ctx, client, http.MethodGet, path, nil, &r)
+
+	return r.Lines, err
+}
+
+func datasourceBillingGroupInvoices() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceBillingGroupInvoicesRead,
+		Schema: map[string]*schema.Schema{
+			"billing_group_id": {
+				Type:        schema.TypeString,
+				Description: "Billing group id",
+				Required:    true,
+			},
+			"invoices": {
+				Type:        schema.TypeList,
+				Description: "Invoices of the billing group",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"invoice_number": {
+							Type:        schema.TypeString,
+							Description: "Invoice number",
+							Computed:    true,
+						},
+						"period_begin": {
+							Type:        schema.TypeString,
+							Description: "Beginning of the billing period",
+							Computed:    true,
+						},
+						"period_end": {
+							Type:        schema.TypeString,
+							Description: "End of the billing period",
+							Computed:    true,
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Description: "State of the invoice, the invoice of the current month is an estimate",
+							Computed:    true,
+						},
+						"total_inc_vat": {
+							Type:        schema.TypeString,
+							Description: "Total including VAT",
+							Computed:    true,
+						},
+						"total_vat_zero": {
+							Type:        schema.TypeString,
+							Description: "Total excluding VAT",
+							Computed:    true,
+						},
+						"currency": {
+							Type:        schema.TypeString,
+							Description: "Currency of the invoice",
+							Computed:    true,
+						},
+						"download_url": {
+							Type:        schema.TypeString,
+							Description: "URL the invoice PDF is downloaded from with the API token",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceBillingGroupInvoicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	id := d.Get("billing_group_id").(string)
+
+	invoices, err := listBillingGroupInvoices(ctx, client, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var list []map[string]interface{}
+	for _, i := range invoices {
+		var downloadURL string
+		if i.DownloadCookie != "" {
+			downloadURL = aivenAPIURL + aivenAPIPath("billing-group", id, "invoice", i.InvoiceNumber, i.DownloadCookie)
+		}
+
+		list = append(list, map[string]interface{}{
+			"invoice_number": i.InvoiceNumber,
+			"period_begin":   i.PeriodBegin,
+			"period_end":     i.PeriodEnd,
+			"state":          i.State,
+			"total_inc_vat":  i.TotalIncVAT,
+			"total_vat_zero": i.TotalVATZero,
+			"currency":       i.Currency,
+			"download_url":   downloadURL,
+		})
+	}
+
+	d.SetId(id)
+	if err := d.Set("invoices", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
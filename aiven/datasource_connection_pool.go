@@ -3,37 +3,87 @@ package aiven
 
 import (
 	"context"
+	"log"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func datasourceConnectionPool() *schema.Resource {
+	s := resourceSchemaAsDatasourceSchema(aivenConnectionPoolSchema, "project", "service_name", "pool_name")
+	s["service_pool_count"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Number of connection pools in the service",
+	}
+	s["service_pool_size"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Sum of the sizes of all connection pools in the service",
+	}
+	s["max_connections"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Backend connections available to the connection pools of the service, 0 when unknown",
+	}
+	s["server_connections"] = &schema.Schema{
+		Type:        schema.TypeInt,
+		Computed:    true,
+		Description: "Open backend connections of the pool user to the pool database, 0 when unknown",
+	}
+
 	return &schema.Resource{
 		ReadContext: datasourceConnectionPoolRead,
-		Schema: resourceSchemaAsDatasourceSchema(aivenConnectionPoolSchema,
-			"project", "service_name", "pool_name"),
+		Schema:      s,
 	}
 }
 
 func datasourceConnectionPoolRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
 	poolName := d.Get("pool_name").(string)
 
-	pools, err := client.ConnectionPools.List(projectName, serviceName)
+	service, err := client.Services.Get(projectName, serviceName)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	for _, pool := range pools {
-		if pool.PoolName == poolName {
-			d.SetId(buildResourceID(projectName, serviceName, poolName))
-			return resourceConnectionPoolRead(ctx, d, m)
+	for _, pool := range service.ConnectionPools {
+		if pool.PoolName != poolName {
+			continue
+		}
+
+		d.SetId(buildResourceID(projectName, serviceName, poolName))
+		if di := resourceConnectionPoolRead(ctx, d, m); di.HasError() {
+			return di
+		}
+
+		size := 0
+		for _, p := range service.ConnectionPools {
+			size += p.PoolSize
+		}
+		maxConnections, serverConnections, err := connectionPoolServiceLimits(
+			ctx, client, projectName, service, pool.Database, pool.Username)
+		if err != nil {
+			log.Printf("[WARN] cannot read connection limits of service %s/%s: %s", projectName, serviceName, err)
+		}
+
+		if err := d.Set("service_pool_count", len(service.ConnectionPools)); err != nil {
+			return diag.FromErr(err)
 		}
+		if err := d.Set("service_pool_size", size); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("max_connections", maxConnections); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("server_connections", serverConnections); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
 	}
 
 	return diag.Errorf("connection pool %s/%s/%s not found",
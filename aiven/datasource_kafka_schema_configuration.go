@@ -3,7 +3,6 @@ package aiven
 import (
 	"context"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -20,7 +19,7 @@ func datasourceKafkaSchemasConfigurationRead(ctx context.Context, d *schema.Reso
 	projectName := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
 
-	_, err := m.(*aiven.Client).KafkaGlobalSchemaConfig.Get(projectName, serviceName)
+	_, err := m.(*providerMeta).client.KafkaGlobalSchemaConfig.Get(projectName, serviceName)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -0,0 +1,145 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func datasourceProjectEventLog() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceProjectEventLogRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Description: "The project the events belong to",
+				Required:    true,
+				Type:        schema.TypeString,
+			},
+			"start_time": {
+				Description:  "Only return events that happened at or after this RFC3339 time",
+				Optional:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"end_time": {
+				Description:  "Only return events that happened before this RFC3339 time",
+				Optional:     true,
+				Type:         schema.TypeString,
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"service_name": {
+				Description: "Only return events of this service",
+				Optional:    true,
+				Type:        schema.TypeString,
+			},
+			"events": {
+				Computed:    true,
+				Description: "Project events, newest first",
+				Type:        schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"actor": {
+							Computed:    true,
+							Description: "User or system that initiated the event",
+							Type:        schema.TypeString,
+						},
+						"time": {
+							Computed:    true,
+							Description: "Time of the event",
+							Type:        schema.TypeString,
+						},
+						"service_name": {
+							Computed:    true,
+							Description: "Service the event is related to",
+							Type:        schema.TypeString,
+						},
+						"event_type": {
+							Computed:    true,
+							Description: "Event type identifier",
+							Type:        schema.TypeString,
+						},
+						"event_desc": {
+							Computed:    true,
+							Description: "Event description",
+							Type:        schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceProjectEventLogRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	projectName := d.Get("project").(string)
+
+	var from, to time.Time
+	if v := d.Get("start_time").(string); v != "" {
+		from, _ = time.Parse(time.RFC3339, v)
+	}
+	if v := d.Get("end_time").(string); v != "" {
+		to, _ = time.Parse(time.RFC3339, v)
+	}
+
+	// the API returns the whole retained event log of a project in a single response
+	events, err := client.Projects.GetEventLog(projectName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	events, err = filterProjectEvents(events, from, to, d.Get("service_name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var list []map[string]interface{}
+	for _, e := range events {
+		list = append(list, map[string]interface{}{
+			"actor":        e.Actor,
+			"time":         e.Time,
+			"service_name": e.ServiceName,
+			"event_type":   e.EventType,
+			"event_desc":   e.EventDesc,
+		})
+	}
+
+	d.SetId(projectName)
+	if err := d.Set("events", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// filterProjectEvents returns events in the [from, to) time range that belong to the given
+// service; zero times and an empty service name do not filter
+func filterProjectEvents(events []*aiven.ProjectEvent, from, to time.Time, serviceName string) ([]*aiven.ProjectEvent, error) {
+	var filtered []*aiven.ProjectEvent
+	for _, e := range events {
+		if serviceName != "" && e.ServiceName != serviceName {
+			continue
+		}
+
+		if !from.IsZero() || !to.IsZero() {
+			t, err := time.Parse(time.RFC3339, e.Time)
+			if err != nil {
+				return nil, fmt.Errorf("cannot parse project event time `%s`: %w", e.Time, err)
+			}
+
+			if (!from.IsZero() && t.Before(from)) || (!to.IsZero() && !t.Before(to)) {
+				continue
+			}
+		}
+
+		filtered = append(filtered, e)
+	}
+
+	return filtered, nil
+}
@@ -0,0 +1,113 @@
+package aiven
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const projectEventLogResponse = `{
+  "events": [
+    {"actor": "jane@example.com", "event_desc": "Powered off service", "event_type": "service_poweroff",
+     "service_name": "pg-1", "time": "2021-09-03T12:00:00Z"},
+    {"actor": "john@example.com", "event_desc": "Changed plan", "event_type": "service_update",
+     "service_name": "kafka-1", "time": "2021-09-02T12:00:00Z"},
+    {"actor": "jane@example.com", "event_desc": "Created service", "event_type": "service_create",
+     "service_name": "pg-1", "time": "2021-09-01T12:00:00Z"}
+  ]
+}`
+
+type cannedRoundTripper struct {
+	t    *testing.T
+	path string
+	body string
+}
+
+func (rt cannedRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Path != rt.path {
+		rt.t.Errorf("unexpected request path %s, expected %s", req.URL.Path, rt.path)
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(rt.body)),
+		Request:    req,
+	}, nil
+}
+
+func Test_datasourceProjectEventLogRead(t *testing.T) {
+	client, err := aiven.NewTokenClient("token", "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Client = &http.Client{Transport: cannedRoundTripper{t: t, path: "/v1/project/test-project/events", body: projectEventLogResponse}}
+
+	d := schema.TestResourceDataRaw(t, datasourceProjectEventLog().Schema, map[string]interface{}{
+		"project":      "test-project",
+		"start_time":   "2021-09-01T13:00:00Z",
+		"service_name": "pg-1",
+	})
+
+	if diags := datasourceProjectEventLogRead(context.Background(), d, &providerMeta{client: client}); diags.HasError() {
+		t.Fatalf("datasourceProjectEventLogRead() error = %v", diags)
+	}
+
+	if d.Id() != "test-project" {
+		t.Errorf("unexpected id %s", d.Id())
+	}
+	if n := d.Get("events.#").(int); n != 1 {
+		t.Fatalf("expected 1 event, got %d", n)
+	}
+	if v := d.Get("events.0.event_type").(string); v != "service_poweroff" {
+		t.Errorf("unexpected event type %s", v)
+	}
+	if v := d.Get("events.0.actor").(string); v != "jane@example.com" {
+		t.Errorf("unexpected actor %s", v)
+	}
+}
+
+func Test_filterProjectEvents(t *testing.T) {
+	events := []*aiven.ProjectEvent{
+		{ServiceName: "pg-1", Time: "2021-09-03T12:00:00Z"},
+		{ServiceName: "kafka-1", Time: "2021-09-02T12:00:00Z"},
+		{ServiceName: "", Time: "2021-09-01T12:00:00Z"},
+	}
+	day := func(d int) time.Time { return time.Date(2021, 9, d, 12, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name        string
+		from, to    time.Time
+		serviceName string
+		want        int
+		wantErr     bool
+	}{
+		{"no filters", time.Time{}, time.Time{}, "", 3, false},
+		{"from is inclusive", day(2), time.Time{}, "", 2, false},
+		{"to is exclusive", time.Time{}, day(2), "", 1, false},
+		{"range", day(2), day(3), "", 1, false},
+		{"service", time.Time{}, time.Time{}, "kafka-1", 1, false},
+		{"service and range", day(1), day(3), "pg-1", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterProjectEvents(events, tt.from, tt.to, tt.serviceName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("filterProjectEvents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("filterProjectEvents() returned %d events, want %d", len(got), tt.want)
+			}
+		})
+	}
+
+	if _, err := filterProjectEvents([]*aiven.ProjectEvent{{Time: "yesterday"}}, day(1), time.Time{}, ""); err == nil {
+		t.Errorf("filterProjectEvents() expected an error for an invalid event time")
+	}
+}
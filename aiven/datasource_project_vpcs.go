@@ -0,0 +1,159 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceProjectVPCs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceProjectVPCsRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Description: "The project the VPCs belong to",
+				Required:    true,
+				Type:        schema.TypeString,
+			},
+			"supernet": {
+				Description:  "Network address range in which the next free network CIDR is looked for",
+				Optional:     true,
+				Default:      "10.0.0.0/8",
+				Type:         schema.TypeString,
+				ValidateFunc: validateNetworkCIDR,
+			},
+			"prefix_length": {
+				Description: "Prefix length of the suggested next free network CIDR",
+				Optional:    true,
+				Default:     24,
+				Type:        schema.TypeInt,
+			},
+			"next_free_cidr": {
+				Computed:    true,
+				Description: "First network CIDR in the supernet that does not overlap with any project VPC or peered network",
+				Type:        schema.TypeString,
+			},
+			"vpcs": {
+				Computed:    true,
+				Description: "List of project VPCs",
+				Type:        schema.TypeList,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Computed:    true,
+							Description: "Project VPC identifier",
+							Type:        schema.TypeString,
+						},
+						"cloud_name": {
+							Computed:    true,
+							Description: "Cloud the VPC is in",
+							Type:        schema.TypeString,
+						},
+						"network_cidr": {
+							Computed:    true,
+							Description: "Network address range used by the VPC",
+							Type:        schema.TypeString,
+						},
+						"state": {
+							Computed:    true,
+							Description: "State of the VPC",
+							Type:        schema.TypeString,
+						},
+						"peering_connections": {
+							Computed:    true,
+							Description: "Peering connections of the VPC",
+							Type:        schema.TypeList,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"peer_cloud_account": {
+										Computed:    true,
+										Description: "Identifier of the peered cloud account",
+										Type:        schema.TypeString,
+									},
+									"peer_vpc": {
+										Computed:    true,
+										Description: "Identifier or name of the peered VPC",
+										Type:        schema.TypeString,
+									},
+									"peer_region": {
+										Computed:    true,
+										Description: "Region of the peered VPC",
+										Type:        schema.TypeString,
+									},
+									"state": {
+										Computed:    true,
+										Description: "State of the peering connection",
+										Type:        schema.TypeString,
+									},
+									"user_peer_network_cidrs": {
+										Computed:    true,
+										Description: "List of private IPv4 ranges routed through the peering connection",
+										Type:        schema.TypeList,
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceProjectVPCsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	projectName := d.Get("project").(string)
+	vpcs, err := client.VPCs.List(projectName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var used []namedCIDR
+	var vpcList []map[string]interface{}
+	for _, vpc := range vpcs {
+		used = append(used, projectVPCNetworks(vpc)...)
+
+		var peerings []map[string]interface{}
+		for _, pc := range vpc.PeeringConnections {
+			peerRegion := ""
+			if pc.PeerRegion != nil {
+				peerRegion = *pc.PeerRegion
+			}
+
+			peerings = append(peerings, map[string]interface{}{
+				"peer_cloud_account":      pc.PeerCloudAccount,
+				"peer_vpc":                pc.PeerVPC,
+				"peer_region":             peerRegion,
+				"state":                   pc.State,
+				"user_peer_network_cidrs": pc.UserPeerNetworkCIDRs,
+			})
+		}
+
+		vpcList = append(vpcList, map[string]interface{}{
+			"id":                  buildResourceID(projectName, vpc.ProjectVPCID),
+			"cloud_name":          vpc.CloudName,
+			"network_cidr":        vpc.NetworkCIDR,
+			"state":               vpc.State,
+			"peering_connections": peerings,
+		})
+	}
+
+	next, err := nextFreeCIDR(d.Get("supernet").(string), d.Get("prefix_length").(int), used)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(projectName)
+	if err := d.Set("vpcs", vpcList); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("next_free_cidr", next); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
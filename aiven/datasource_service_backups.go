@@ -0,0 +1,106 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"net/http"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serviceBackup is a backup of a service, the backups of aiven.Service have no names so
+// they are listed through aivenAPIRequest
+type serviceBackup struct {
+	BackupName string `json:"backup_name"`
+	BackupTime string `json:"backup_time"`
+	DataSize   int    `json:"data_size"`
+}
+
+type serviceBackupsResponse struct {
+	Backups []serviceBackup `json:"backups"`
+}
+
+func listServiceBackups(ctx context.Context, client *aiven.Client, project, serviceName string) ([]serviceBackup, error) {
+	var r serviceBackupsResponse
+	path := aivenAPIPath("project", project, "service", serviceName, "backups")
+	err := aivenAPIRequest(ctx, client, http.MethodGet, path, nil, &r)
+
+	return r.Backups, err
+}
+
+func datasourceServiceBackups() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceServiceBackupsRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project the service belongs to",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Service to list the backups of",
+			},
+			"backups": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Available backups of the service, oldest first",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"backup_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the backup",
+						},
+						"backup_time": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time the backup was taken",
+						},
+						"data_size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Size of the backup in bytes",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceServiceBackupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	backups, err := listServiceBackups(ctx, client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// RFC3339 times in the same zone sort lexically
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].BackupTime < backups[j].BackupTime
+	})
+
+	var list []map[string]interface{}
+	for _, b := range backups {
+		list = append(list, map[string]interface{}{
+			"backup_name": b.BackupName,
+			"backup_time": b.BackupTime,
+			"data_size":   b.DataSize,
+		})
+	}
+
+	d.SetId(buildResourceID(projectName, serviceName))
+	if err := d.Set("backups", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceStaticIPs() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceStaticIPsRead,
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Project name",
+			},
+			"static_ips": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Static IP addresses of the project",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"static_ip_address_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Static IP address ID",
+						},
+						"cloud_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Cloud the static IP address is allocated in",
+						},
+						"ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Static IP address",
+						},
+						"service_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Service the static IP address is associated with",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "State of the static IP address",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceStaticIPsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	projectName := d.Get("project").(string)
+
+	ips, err := listStaticIPs(ctx, client, projectName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var list []map[string]interface{}
+	for _, ip := range ips {
+		list = append(list, map[string]interface{}{
+			"static_ip_address_id": ip.StaticIPAddressID,
+			"cloud_name":           ip.CloudName,
+			"ip_address":           ip.IPAddress,
+			"service_name":         ip.ServiceName,
+			"state":                ip.State,
+		})
+	}
+
+	d.SetId(projectName)
+	if err := d.Set("static_ips", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/go-sql-driver/mysql"
+)
+
+// mysqlDatabasePrivileges lists the privileges that can be granted on a MySQL database
+var mysqlDatabasePrivileges = []string{
+	"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "REFERENCES", "INDEX", "ALTER",
+	"CREATE TEMPORARY TABLES", "LOCK TABLES", "EXECUTE", "CREATE VIEW", "SHOW VIEW",
+	"CREATE ROUTINE", "ALTER ROUTINE", "EVENT", "TRIGGER",
+}
+
+// mysqlTablePrivileges lists the privileges that can be granted on a MySQL table
+var mysqlTablePrivileges = []string{
+	"SELECT", "INSERT", "UPDATE", "DELETE", "CREATE", "DROP", "REFERENCES", "INDEX", "ALTER",
+	"CREATE VIEW", "SHOW VIEW", "TRIGGER",
+}
+
+// mysqlServiceDSN returns the driver DSN of the admin user of a MySQL service, the
+// connection is verified against the project CA; tests replace it to run the MySQL
+// resources against a local server
+var mysqlServiceDSN = func(client *aiven.Client, projectName, serviceName string) (string, error) {
+	s, err := client.Services.Get(projectName, serviceName)
+	if err != nil {
+		return "", err
+	}
+
+	if s.Type != ServiceTypeMySQL {
+		return "", fmt.Errorf("service %s/%s is of type %s, expected %s", projectName, serviceName, s.Type, ServiceTypeMySQL)
+	}
+
+	u, err := url.Parse(s.URI)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("service %s/%s has no valid connection URI, is it running?", projectName, serviceName)
+	}
+
+	ca, err := client.CA.Get(projectName)
+	if err != nil {
+		return "", fmt.Errorf("cannot get project CA certificate: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(ca)) {
+		return "", fmt.Errorf("cannot parse project %s CA certificate", projectName)
+	}
+
+	// the server name differs per service, so the configs of services verified at the same
+	// time must not replace each other
+	tlsConfigName := "aiven-" + projectName + "-" + serviceName
+	err = mysql.RegisterTLSConfig(tlsConfigName, &tls.Config{RootCAs: pool, ServerName: u.Hostname()})
+	if err != nil {
+		return "", err
+	}
+
+	cfg := mysql.NewConfig()
+	cfg.User = u.User.Username()
+	cfg.Passwd, _ = u.User.Password()
+	cfg.Net = "tcp"
+	cfg.Addr = u.Host
+	cfg.TLSConfig = tlsConfigName
+
+	return cfg.FormatDSN(), nil
+}
+
+// mysqlConnect opens a connection to a MySQL service through its SQL endpoint
+func mysqlConnect(ctx context.Context, client *aiven.Client, projectName, serviceName string) (*sql.DB, error) {
+	dsn, err := mysqlServiceDSN(client, projectName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("cannot connect to service %s/%s: %w", projectName, serviceName, err)
+	}
+
+	return db, nil
+}
+
+// mysqlExec runs the statements one by one, MySQL commits GRANT and REVOKE implicitly so
+// they cannot be grouped in a transaction
+func mysqlExec(ctx context.Context, db *sql.DB, statements ...string) error {
+	for _, s := range statements {
+		if _, err := db.ExecContext(ctx, s); err != nil {
+			return fmt.Errorf("cannot execute `%s`: %w", s, err)
+		}
+	}
+
+	return nil
+}
+
+// mysqlQuoteIdentifier quotes a database or table name, * is left as is
+func mysqlQuoteIdentifier(name string) string {
+	if name == "*" {
+		return name
+	}
+
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// mysqlQuoteString quotes a string literal like a user name
+func mysqlQuoteString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", "''").Replace(s) + "'"
+}
+
+// mysqlAccount returns the account of a service user, Aiven creates users for any host
+func mysqlAccount(username string) string {
+	return mysqlQuoteString(username) + "@'%'"
+}
+
+// validateMySQLPrivileges checks that the privileges can be granted on the database or table
+func validateMySQLPrivileges(table string, privileges []string) error {
+	allowed := mysqlDatabasePrivileges
+	if table != "*" {
+		allowed = mysqlTablePrivileges
+	}
+
+	for _, p := range privileges {
+		valid := false
+		for _, a := range allowed {
+			if p == a {
+				valid = true
+			}
+		}
+
+		if !valid {
+			return fmt.Errorf("privilege `%s` cannot be granted here, expected one of %v", p, allowed)
+		}
+	}
+
+	return nil
+}
+
+// diffMySQLPrivileges returns the sorted privileges to grant and to revoke to get from the
+// current privileges to the desired ones
+func diffMySQLPrivileges(current, desired []string) (grant, revoke []string) {
+	have := make(map[string]bool)
+	for _, p := range current {
+		have[p] = true
+	}
+
+	want := make(map[string]bool)
+	for _, p := range desired {
+		want[p] = true
+		if !have[p] {
+			grant = append(grant, p)
+		}
+	}
+
+	for _, p := range current {
+		if !want[p] {
+			revoke = append(revoke, p)
+		}
+	}
+	sort.Strings(grant)
+	sort.Strings(revoke)
+
+	return grant, revoke
+}
+
+// mysqlGrantStatements builds the statements that turn the current privileges of the user
+// on the database or table into the desired ones
+func mysqlGrantStatements(database, table, username string, current, desired []string) []string {
+	target := mysqlQuoteIdentifier(database) + "." + mysqlQuoteIdentifier(table)
+	grant, revoke := diffMySQLPrivileges(current, desired)
+
+	var statements []string
+	if len(revoke) > 0 {
+		statements = append(statements, fmt.Sprintf("REVOKE %s ON %s FROM %s",
+			strings.Join(revoke, ", "), target, mysqlAccount(username)))
+	}
+	if len(grant) > 0 {
+		statements = append(statements, fmt.Sprintf("GRANT %s ON %s TO %s",
+			strings.Join(grant, ", "), target, mysqlAccount(username)))
+	}
+
+	return statements
+}
+
+// readMySQLGrants returns the sorted privileges the user holds on the database or table
+func readMySQLGrants(ctx context.Context, db *sql.DB, database, table, username string) ([]string, error) {
+	grantee := mysqlAccount(username)
+
+	var rows *sql.Rows
+	var err error
+	if table == "*" {
+		rows, err = db.QueryContext(ctx, `SELECT PRIVILEGE_TYPE FROM information_schema.SCHEMA_PRIVILEGES
+			WHERE GRANTEE = ? AND TABLE_SCHEMA = ?`, grantee, database)
+	} else {
+		rows, err = db.QueryContext(ctx, `SELECT PRIVILEGE_TYPE FROM information_schema.TABLE_PRIVILEGES
+			WHERE GRANTEE = ? AND TABLE_SCHEMA = ? AND TABLE_NAME = ?`, grantee, database, table)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var privileges []string
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, err
+		}
+		privileges = append(privileges, p)
+	}
+	sort.Strings(privileges)
+
+	return privileges, rows.Err()
+}
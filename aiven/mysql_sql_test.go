@@ -0,0 +1,153 @@
+package aiven
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_mysqlGrantStatements(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   string
+		current []string
+		desired []string
+		want    []string
+	}{
+		{
+			"grant on database",
+			"*", nil, []string{"SELECT", "INSERT"},
+			[]string{"GRANT INSERT, SELECT ON `app`.* TO 'reader'@'%'"},
+		},
+		{
+			"change on table",
+			"users", []string{"SELECT", "DELETE"}, []string{"SELECT", "UPDATE"},
+			[]string{
+				"REVOKE DELETE ON `app`.`users` FROM 'reader'@'%'",
+				"GRANT UPDATE ON `app`.`users` TO 'reader'@'%'",
+			},
+		},
+		{
+			"revoke all",
+			"*", []string{"SELECT"}, nil,
+			[]string{"REVOKE SELECT ON `app`.* FROM 'reader'@'%'"},
+		},
+		{
+			"no changes",
+			"*", []string{"SELECT"}, []string{"SELECT"},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mysqlGrantStatements("app", tt.table, "reader", tt.current, tt.desired)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mysqlGrantStatements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mysqlQuote(t *testing.T) {
+	if got := mysqlQuoteIdentifier("we`ird"); got != "`we``ird`" {
+		t.Errorf("mysqlQuoteIdentifier() = %s", got)
+	}
+	if got := mysqlAccount(`o'bri\en`); got != `'o''bri\\en'@'%'` {
+		t.Errorf("mysqlAccount() = %s", got)
+	}
+}
+
+func Test_validateMySQLPrivileges(t *testing.T) {
+	if err := validateMySQLPrivileges("*", []string{"SELECT", "CREATE ROUTINE"}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := validateMySQLPrivileges("users", []string{"CREATE ROUTINE"}); err == nil {
+		t.Error("expected an error for CREATE ROUTINE on a table")
+	}
+	if err := validateMySQLPrivileges("*", []string{"SUPER"}); err == nil {
+		t.Error("expected an error for a global privilege")
+	}
+}
+
+func Test_resourceMySQLGrantRead_missingService(t *testing.T) {
+	orig := mysqlServiceDSN
+	mysqlServiceDSN = func(*aiven.Client, string, string) (string, error) {
+		return "", aiven.Error{Message: "Service not found", Status: 404}
+	}
+	defer func() { mysqlServiceDSN = orig }()
+
+	d := schema.TestResourceDataRaw(t, aivenMySQLGrantSchema, map[string]interface{}{})
+	d.SetId("test/mysql/reader/app/*")
+	if di := resourceMySQLGrantRead(context.Background(), d, &providerMeta{}); di.HasError() || d.Id() != "" {
+		t.Errorf("expected the grant of a missing service to be removed, got %s %v", d.Id(), di)
+	}
+}
+
+// TestMySQLGrant_local runs the MySQL grant resource against a local server, for example
+// docker run -e MYSQL_ROOT_PASSWORD=test -p 3306:3306 mysql:8 with
+// AIVEN_MYSQL_TEST_DSN=root:test@tcp(localhost:3306)/
+func TestMySQLGrant_local(t *testing.T) {
+	dsn := os.Getenv("AIVEN_MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("AIVEN_MYSQL_TEST_DSN is not set")
+	}
+
+	orig := mysqlServiceDSN
+	mysqlServiceDSN = func(*aiven.Client, string, string) (string, error) { return dsn, nil }
+	defer func() { mysqlServiceDSN = orig }()
+
+	ctx := context.Background()
+	meta := &providerMeta{}
+	db, err := mysqlConnect(ctx, meta.client, "test", "mysql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = mysqlExec(ctx, db,
+		"DROP DATABASE IF EXISTS tf_test",
+		"DROP USER IF EXISTS 'tf_test_reader'@'%'",
+		"CREATE DATABASE tf_test",
+		"CREATE USER 'tf_test_reader'@'%' IDENTIFIED BY 'tf-test-password'",
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = mysqlExec(ctx, db, "DROP DATABASE IF EXISTS tf_test", "DROP USER IF EXISTS 'tf_test_reader'@'%'")
+	}()
+
+	d := schema.TestResourceDataRaw(t, aivenMySQLGrantSchema, map[string]interface{}{
+		"project":       "test",
+		"service_name":  "mysql",
+		"username":      "tf_test_reader",
+		"database_name": "tf_test",
+		"privileges":    []interface{}{"SELECT", "INSERT"},
+	})
+	if di := resourceMySQLGrantCreate(ctx, d, meta); di.HasError() {
+		t.Fatal(di)
+	}
+
+	// drift made outside of Terraform is read back
+	if err := mysqlExec(ctx, db, "GRANT DELETE ON `tf_test`.* TO 'tf_test_reader'@'%'"); err != nil {
+		t.Fatal(err)
+	}
+	if di := resourceMySQLGrantRead(ctx, d, meta); di.HasError() {
+		t.Fatal(di)
+	}
+	got := flattenToString(d.Get("privileges").(*schema.Set).List())
+	if len(got) != 3 {
+		t.Errorf("expected the DELETE privilege to be read, got %v", got)
+	}
+
+	if di := resourceMySQLGrantDelete(ctx, d, meta); di.HasError() {
+		t.Fatal(di)
+	}
+	if di := resourceMySQLGrantRead(ctx, d, meta); di.HasError() || d.Id() != "" {
+		t.Errorf("expected the grant to be gone, got %s %v", d.Id(), di)
+	}
+}
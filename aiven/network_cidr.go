@@ -0,0 +1,169 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+)
+
+// namedCIDR is a network CIDR together with a description of where it is used
+type namedCIDR struct {
+	cidr  string
+	owner string
+}
+
+// parseIPv4CIDR parses a network CIDR and makes sure that it is an IPv4 network address
+func parseIPv4CIDR(cidr string) (*net.IPNet, error) {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid network CIDR `%s`: %w", cidr, err)
+	}
+
+	if ip.To4() == nil {
+		return nil, fmt.Errorf("invalid network CIDR `%s`: only IPv4 networks are supported", cidr)
+	}
+
+	if !ip.Equal(network.IP) {
+		return nil, fmt.Errorf("invalid network CIDR `%s`: host bits are set, did you mean `%s`?", cidr, network)
+	}
+
+	return network, nil
+}
+
+// cidrsOverlap checks if two networks share at least one address
+func cidrsOverlap(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// findCIDROverlap returns an error describing the first network from the list that
+// overlaps with the given one; networks that cannot be parsed are skipped
+func findCIDROverlap(cidr string, others []namedCIDR) error {
+	network, err := parseIPv4CIDR(cidr)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range others {
+		n, err := parseIPv4CIDR(o.cidr)
+		if err != nil {
+			continue
+		}
+
+		if cidrsOverlap(network, n) {
+			return fmt.Errorf("network CIDR `%s` overlaps with `%s` of %s", cidr, o.cidr, o.owner)
+		}
+	}
+
+	return nil
+}
+
+// validatePeerNetworkCIDRs checks that user peer network CIDRs are valid and do not overlap
+// with each other, with the project VPC network and with networks of other peering connections;
+// host bits are left to validateNetworkCIDR like for the project VPC network
+func validatePeerNetworkCIDRs(cidrs []string, vpcNetworkCIDR string, others []namedCIDR) error {
+	used := append([]namedCIDR{{cidr: vpcNetworkCIDR, owner: "the project VPC"}}, others...)
+	for _, c := range cidrs {
+		if c == "" {
+			continue
+		}
+
+		c, err := normalizeNetworkCIDR(c)
+		if err != nil {
+			return err
+		}
+
+		if err := findCIDROverlap(c, used); err != nil {
+			return err
+		}
+
+		used = append(used, namedCIDR{cidr: c, owner: "this peering connection"})
+	}
+
+	return nil
+}
+
+// validateNetworkCIDR is a ValidateFunc that ensures a string is an IPv4 network CIDR without
+// host bits, nextFreeCIDR rejects such networks and the overlap checks skip them
+func validateNetworkCIDR(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := parseIPv4CIDR(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q: %s", k, err))
+	}
+
+	return
+}
+
+// normalizeNetworkCIDR parses an IPv4 network CIDR and returns it with host bits cleared
+func normalizeNetworkCIDR(cidr string) (string, error) {
+	ip, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", fmt.Errorf("invalid network CIDR `%s`: %w", cidr, err)
+	}
+
+	if ip.To4() == nil {
+		return "", fmt.Errorf("invalid network CIDR `%s`: only IPv4 networks are supported", cidr)
+	}
+
+	return network.String(), nil
+}
+
+// nextFreeCIDR finds the first network of the given prefix length inside the supernet
+// that does not overlap with any of the used networks
+func nextFreeCIDR(supernet string, prefixLength int, used []namedCIDR) (string, error) {
+	super, err := parseIPv4CIDR(supernet)
+	if err != nil {
+		return "", err
+	}
+
+	superPrefix, _ := super.Mask.Size()
+	if prefixLength < superPrefix || prefixLength > 32 {
+		return "", fmt.Errorf("prefix length %d must be between %d and 32", prefixLength, superPrefix)
+	}
+
+	var networks []*net.IPNet
+	for _, u := range used {
+		if n, err := parseIPv4CIDR(u.cidr); err == nil {
+			networks = append(networks, n)
+		}
+	}
+
+	size := uint64(1) << uint(32-prefixLength)
+	start := uint64(ipv4ToUint32(super.IP))
+	end := start + (uint64(1) << uint(32-superPrefix))
+
+	for candidate := start; candidate+size <= end; {
+		n := &net.IPNet{IP: uint32ToIPv4(uint32(candidate)), Mask: net.CIDRMask(prefixLength, 32)}
+
+		next := uint64(0)
+		for _, u := range networks {
+			if !cidrsOverlap(n, u) {
+				continue
+			}
+
+			// Skip to the first aligned network after the used one
+			ones, _ := u.Mask.Size()
+			usedEnd := uint64(ipv4ToUint32(u.IP)) + (uint64(1) << uint(32-ones))
+			if aligned := (usedEnd + size - 1) / size * size; aligned > next {
+				next = aligned
+			}
+		}
+
+		if next == 0 {
+			return n.String(), nil
+		}
+		candidate = next
+	}
+
+	return "", fmt.Errorf("there is no free /%d network left in %s", prefixLength, supernet)
+}
+
+func ipv4ToUint32(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+func uint32ToIPv4(v uint32) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, v)
+
+	return ip
+}
@@ -0,0 +1,98 @@
+package aiven
+
+import (
+	"testing"
+)
+
+func Test_validatePeerNetworkCIDRs(t *testing.T) {
+	others := []namedCIDR{
+		{cidr: "10.1.0.0/16", owner: "peering connection to 123/vpc-1"},
+	}
+
+	tests := []struct {
+		name    string
+		cidrs   []string
+		wantErr bool
+	}{
+		{"no overlap", []string{"10.2.0.0/16", "192.168.0.0/24"}, false},
+		{"overlaps with project VPC", []string{"10.0.0.128/25"}, true},
+		{"overlaps with other peering", []string{"10.1.2.0/24"}, true},
+		{"overlaps with itself", []string{"10.2.0.0/16", "10.2.1.0/24"}, true},
+		{"supernet of project VPC", []string{"10.0.0.0/8"}, true},
+		{"invalid CIDR", []string{"10.2.0.0/33"}, true},
+		{"host bits set", []string{"10.2.0.1/16"}, false},
+		{"host bits set overlapping", []string{"10.0.0.1/16"}, true},
+		{"IPv6", []string{"fd00::/64"}, true},
+		{"unknown value", []string{""}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePeerNetworkCIDRs(tt.cidrs, "10.0.0.0/24", others)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validatePeerNetworkCIDRs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_nextFreeCIDR(t *testing.T) {
+	tests := []struct {
+		name         string
+		supernet     string
+		prefixLength int
+		used         []string
+		want         string
+		wantErr      bool
+	}{
+		{"empty", "10.0.0.0/16", 24, nil, "10.0.0.0/24", false},
+		{"first used", "10.0.0.0/16", 24, []string{"10.0.0.0/24"}, "10.0.1.0/24", false},
+		{"bigger network used", "10.0.0.0/16", 24, []string{"10.0.0.0/22", "10.0.5.0/24"}, "10.0.4.0/24", false},
+		{"smaller network used", "10.0.0.0/16", 20, []string{"10.0.3.0/24"}, "10.0.16.0/20", false},
+		{"unrelated networks", "10.0.0.0/16", 24, []string{"192.168.0.0/24", "172.16.0.0/12"}, "10.0.0.0/24", false},
+		{"supernet used", "10.0.0.0/16", 24, []string{"10.0.0.0/8"}, "", true},
+		{"full", "10.0.0.0/23", 24, []string{"10.0.0.0/24", "10.0.1.0/24"}, "", true},
+		{"prefix too short", "10.0.0.0/16", 8, nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var used []namedCIDR
+			for _, u := range tt.used {
+				used = append(used, namedCIDR{cidr: u, owner: "test"})
+			}
+
+			got, err := nextFreeCIDR(tt.supernet, tt.prefixLength, used)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("nextFreeCIDR() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("nextFreeCIDR() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateNetworkCIDR(t *testing.T) {
+	tests := []struct {
+		name         string
+		cidr         string
+		wantWarnings bool
+		wantErr      bool
+	}{
+		{"valid", "10.0.0.0/24", false, false},
+		{"host bits set", "192.168.0.1/24", false, true},
+		{"invalid", "10.0.0.0/33", false, true},
+		{"not a CIDR", "10.0.0.0", false, true},
+		{"IPv6", "fd00::/64", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ws, errs := validateNetworkCIDR(tt.cidr, "network_cidr")
+			if (len(ws) > 0) != tt.wantWarnings {
+				t.Errorf("validateNetworkCIDR() warnings = %v, wantWarnings %v", ws, tt.wantWarnings)
+			}
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateNetworkCIDR() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
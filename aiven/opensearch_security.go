@@ -0,0 +1,265 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	// opensearchSecurityAdminUser is the user Aiven creates when security management is
+	// enabled for an OpenSearch service, only it can call the security plugin REST API
+	opensearchSecurityAdminUser = "os-sec-admin"
+
+	// opensearchSecurityAdminPasswordEnv provides the password of os-sec-admin when the
+	// resource has no admin_password, which is the case when importing
+	opensearchSecurityAdminPasswordEnv = "AIVEN_OPENSEARCH_SECURITY_ADMIN_PASSWORD"
+)
+
+// opensearchServiceURL returns the HTTPS endpoint of an OpenSearch service, tests replace
+// it to run the security resources against a fake cluster
+var opensearchServiceURL = func(client *aiven.Client, projectName, serviceName string) (string, error) {
+	s, err := client.Services.Get(projectName, serviceName)
+	if err != nil {
+		return "", err
+	}
+
+	if s.Type != ServiceTypeOpensearch {
+		return "", fmt.Errorf("service %s/%s is of type %s, expected %s", projectName, serviceName, s.Type, ServiceTypeOpensearch)
+	}
+
+	if s.URIParams["host"] == "" {
+		return "", fmt.Errorf("service %s/%s has no connection URI, is it running?", projectName, serviceName)
+	}
+
+	return "https://" + s.URIParams["host"] + ":" + s.URIParams["port"], nil
+}
+
+// opensearchSecurityObject is a kind of object of the OpenSearch security plugin REST API,
+// the resources of all kinds share everything but the body of the object
+type opensearchSecurityObject struct {
+	// kind is the API path of the objects, e.g. roles
+	kind string
+	// title is used in descriptions and errors
+	title string
+	// expand builds the API body of the object out of the resource
+	expand func(d *schema.ResourceData) interface{}
+	// flatten sets the resource from the API body of the object
+	flatten func(d *schema.ResourceData, body json.RawMessage) error
+}
+
+// opensearchSecuritySchema adds the arguments shared by the security plugin resources to
+// the object specific schema
+func opensearchSecuritySchema(o opensearchSecurityObject, s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["project"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Project of the OpenSearch service",
+	}
+	s["service_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "OpenSearch service with security management enabled",
+	}
+	s["name"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		Description:  "Name of the " + o.title,
+		ValidateFunc: validation.StringLenBetween(1, 249),
+	}
+	s["admin_password"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Sensitive:   true,
+		Description: "Password of the os-sec-admin user, defaults to " + opensearchSecurityAdminPasswordEnv,
+	}
+	s["reserved"] = &schema.Schema{
+		Type:        schema.TypeBool,
+		Computed:    true,
+		Description: "Whether the " + o.title + " is reserved by the security plugin",
+	}
+
+	return s
+}
+
+// opensearchIndexPatternSchema validates index patterns the same way as the index of the
+// rules of aiven_opensearch_acl_config
+func opensearchIndexPatternSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		ValidateFunc: validation.StringLenBetween(1, 249),
+	}
+}
+
+func opensearchSecurityPath(kind, name string) string {
+	return "/_plugins/_security/api/" + kind + "/" + url.PathEscape(name)
+}
+
+// opensearchSecurityRequest calls the security plugin REST API of the service as os-sec-admin,
+// failures are returned as aiven.Error so that aiven.IsNotFound works on them
+func opensearchSecurityRequest(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client *aiven.Client,
+	method, path string,
+	in, out interface{},
+) error {
+	project, serviceName := d.Get("project").(string), d.Get("service_name").(string)
+	base, err := opensearchServiceURL(client, project, serviceName)
+	if err != nil {
+		return err
+	}
+
+	password := d.Get("admin_password").(string)
+	if password == "" {
+		password = os.Getenv(opensearchSecurityAdminPasswordEnv)
+	}
+	if password == "" {
+		return fmt.Errorf("admin_password or %s is required to manage the security of service %s/%s",
+			opensearchSecurityAdminPasswordEnv, project, serviceName)
+	}
+
+	var body []byte
+	if in != nil {
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, base+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(opensearchSecurityAdminUser, password)
+
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rsp.Body.Close() }()
+
+	b, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return err
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return aiven.Error{Message: string(b), Status: rsp.StatusCode}
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("cannot unmarshal JSON `%s`, error: %w", b, err)
+	}
+
+	return nil
+}
+
+// resourceOpensearchSecurityObject builds the resource of a kind of security plugin objects
+func resourceOpensearchSecurityObject(o opensearchSecurityObject, s map[string]*schema.Schema) *schema.Resource {
+	read := func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		project, serviceName, name := splitResourceID3(d.Id())
+		if err := d.Set("project", project); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("service_name", serviceName); err != nil {
+			return diag.FromErr(err)
+		}
+
+		var r map[string]json.RawMessage
+		err := opensearchSecurityRequest(ctx, d, m.(*providerMeta).client, http.MethodGet, opensearchSecurityPath(o.kind, name), nil, &r)
+		if err != nil {
+			return diag.FromErr(resourceReadHandleNotFound(err, d))
+		}
+
+		body, ok := r[name]
+		if !ok {
+			d.SetId("")
+			return nil
+		}
+
+		var meta struct {
+			Reserved bool `json:"reserved"`
+		}
+		if err := json.Unmarshal(body, &meta); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("name", name); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("reserved", meta.Reserved); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := o.flatten(d, body); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+
+	put := func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		name := d.Get("name").(string)
+		err := opensearchSecurityRequest(ctx, d, m.(*providerMeta).client, http.MethodPut, opensearchSecurityPath(o.kind, name), o.expand(d), nil)
+		if err != nil {
+			return diag.Errorf("cannot save %s %s: %s", o.title, name, err)
+		}
+
+		d.SetId(buildResourceID(d.Get("project").(string), d.Get("service_name").(string), name))
+
+		return read(ctx, d, m)
+	}
+
+	return &schema.Resource{
+		CreateContext: put,
+		ReadContext:   read,
+		UpdateContext: put,
+		DeleteContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			_, _, name := splitResourceID3(d.Id())
+			err := opensearchSecurityRequest(ctx, d, m.(*providerMeta).client, http.MethodDelete, opensearchSecurityPath(o.kind, name), nil, nil)
+			if err != nil && !aiven.IsNotFound(err) {
+				return diag.Errorf("cannot delete %s %s: %s", o.title, name, err)
+			}
+
+			return nil
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+				if len(strings.Split(d.Id(), "/")) != 3 {
+					return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>/<name>", d.Id())
+				}
+
+				if di := read(ctx, d, m); di.HasError() {
+					return nil, fmt.Errorf("cannot get %s: %v", o.title, di)
+				}
+
+				if d.Id() == "" {
+					return nil, fmt.Errorf("%s does not exist", o.title)
+				}
+
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: opensearchSecuritySchema(o, s),
+	}
+}
@@ -0,0 +1,190 @@
+package aiven
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// newTestOpensearchSecurityAPI fakes the security plugin REST API of an OpenSearch service for
+// the duration of the test, the returned map holds the objects of the given kind by name
+func newTestOpensearchSecurityAPI(t *testing.T, kind string) map[string]json.RawMessage {
+	objects := make(map[string]json.RawMessage)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, _ := r.BasicAuth(); user != opensearchSecurityAdminUser || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		name := strings.TrimPrefix(r.URL.Path, "/_plugins/_security/api/"+kind+"/")
+		switch r.Method {
+		case http.MethodPut:
+			b, _ := ioutil.ReadAll(r.Body)
+			objects[name] = b
+		case http.MethodDelete:
+			delete(objects, name)
+		default:
+			body, ok := objects[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]json.RawMessage{name: body})
+		}
+	}))
+
+	orig := opensearchServiceURL
+	opensearchServiceURL = func(_ *aiven.Client, _, _ string) (string, error) { return srv.URL, nil }
+	t.Cleanup(func() {
+		opensearchServiceURL = orig
+		srv.Close()
+	})
+
+	return objects
+}
+
+func Test_resourceOpensearchSecurityRole(t *testing.T) {
+	objects := newTestOpensearchSecurityAPI(t, "roles")
+
+	r := resourceOpensearchSecurityRole()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project":             "test-project",
+		"service_name":        "os1",
+		"name":                "logs-reader",
+		"admin_password":      "secret",
+		"cluster_permissions": []interface{}{"cluster_composite_ops_ro"},
+		"index_permissions": []interface{}{map[string]interface{}{
+			"index_patterns":  []interface{}{"logs-*"},
+			"dls":             `{"term": {"team": "search"}}`,
+			"fls":             []interface{}{"~secret"},
+			"allowed_actions": []interface{}{"read"},
+		}},
+	})
+
+	if di := r.CreateContext(context.Background(), d, &providerMeta{client: &aiven.Client{}}); di.HasError() {
+		t.Fatalf("CreateContext() error = %v", di)
+	}
+	if d.Id() != "test-project/os1/logs-reader" {
+		t.Errorf("ID = %s, want test-project/os1/logs-reader", d.Id())
+	}
+
+	var sent opensearchSecurityRole
+	_ = json.Unmarshal(objects["logs-reader"], &sent)
+	if want := []string{"~secret"}; !reflect.DeepEqual(sent.IndexPermissions[0].FLS, want) {
+		t.Errorf("fls sent = %v, want %v", sent.IndexPermissions[0].FLS, want)
+	}
+	if got := d.Get("index_permissions.0.dls"); got != `{"term": {"team": "search"}}` {
+		t.Errorf("dls read back = %v", got)
+	}
+
+	// a role deleted outside of Terraform is removed from the state
+	delete(objects, "logs-reader")
+	if di := r.ReadContext(context.Background(), d, &providerMeta{client: &aiven.Client{}}); di.HasError() {
+		t.Fatalf("ReadContext() error = %v", di)
+	}
+	if d.Id() != "" {
+		t.Errorf("ID of a deleted role = %s, want empty", d.Id())
+	}
+}
+
+func Test_resourceOpensearchSecurityRoleMapping(t *testing.T) {
+	objects := newTestOpensearchSecurityAPI(t, "rolesmapping")
+	meta := &providerMeta{client: &aiven.Client{}}
+
+	r := resourceOpensearchSecurityRoleMapping()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project":        "test-project",
+		"service_name":   "os1",
+		"name":           "logs-reader",
+		"admin_password": "secret",
+		"backend_roles":  []interface{}{"cn=search,ou=groups"},
+		"users":          []interface{}{"jane"},
+	})
+
+	if di := r.CreateContext(context.Background(), d, meta); di.HasError() {
+		t.Fatalf("CreateContext() error = %v", di)
+	}
+	if d.Id() != "test-project/os1/logs-reader" {
+		t.Errorf("ID = %s, want test-project/os1/logs-reader", d.Id())
+	}
+
+	var sent opensearchSecurityRoleMapping
+	_ = json.Unmarshal(objects["logs-reader"], &sent)
+	want := opensearchSecurityRoleMapping{BackendRoles: []string{"cn=search,ou=groups"}, Hosts: []string{}, Users: []string{"jane"}}
+	if !reflect.DeepEqual(sent, want) {
+		t.Errorf("role mapping sent = %+v, want %+v", sent, want)
+	}
+
+	// users mapped outside of Terraform show up in the state
+	objects["logs-reader"] = json.RawMessage(`{"backend_roles": ["cn=search,ou=groups"], "users": ["jane", "john"], "reserved": true}`)
+	if di := r.ReadContext(context.Background(), d, meta); di.HasError() {
+		t.Fatalf("ReadContext() error = %v", di)
+	}
+	users := flattenToString(d.Get("users").(*schema.Set).List())
+	sort.Strings(users)
+	if !reflect.DeepEqual(users, []string{"jane", "john"}) {
+		t.Errorf("users read back = %v", users)
+	}
+	if d.Get("reserved") != true {
+		t.Error("expected a reserved role mapping")
+	}
+
+	if di := r.DeleteContext(context.Background(), d, meta); di.HasError() {
+		t.Fatalf("DeleteContext() error = %v", di)
+	}
+	if _, ok := objects["logs-reader"]; ok {
+		t.Error("expected the role mapping to be deleted")
+	}
+}
+
+func Test_resourceOpensearchSecurityActionGroup(t *testing.T) {
+	objects := newTestOpensearchSecurityAPI(t, "actiongroups")
+	meta := &providerMeta{client: &aiven.Client{}}
+
+	r := resourceOpensearchSecurityActionGroup()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"project":         "test-project",
+		"service_name":    "os1",
+		"name":            "logs-write",
+		"admin_password":  "secret",
+		"type":            "index",
+		"allowed_actions": []interface{}{"indices:data/write/index", "indices:data/write/bulk*"},
+	})
+
+	if di := r.CreateContext(context.Background(), d, meta); di.HasError() {
+		t.Fatalf("CreateContext() error = %v", di)
+	}
+
+	var sent opensearchSecurityActionGroup
+	_ = json.Unmarshal(objects["logs-write"], &sent)
+	if sent.Type != "index" || len(sent.AllowedActions) != 2 {
+		t.Errorf("action group sent = %+v", sent)
+	}
+	if got := d.Get("allowed_actions").(*schema.Set).Len(); got != 2 {
+		t.Errorf("allowed_actions read back has %d actions, want 2", got)
+	}
+
+	if di := r.DeleteContext(context.Background(), d, meta); di.HasError() {
+		t.Fatalf("DeleteContext() error = %v", di)
+	}
+	if _, ok := objects["logs-write"]; ok {
+		t.Error("expected the action group to be deleted")
+	}
+
+	// a deleted action group is removed from the state
+	if di := r.ReadContext(context.Background(), d, meta); di.HasError() {
+		t.Fatalf("ReadContext() error = %v", di)
+	}
+	if d.Id() != "" {
+		t.Errorf("ID of a deleted action group = %s, want empty", d.Id())
+	}
+}
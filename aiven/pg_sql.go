@@ -0,0 +1,369 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+// pgObjectPrivileges lists the privileges that can be granted per PostgreSQL object type,
+// privileges on types can only be granted as default privileges
+var pgObjectPrivileges = map[string][]string{
+	"schema":   {"USAGE", "CREATE"},
+	"table":    {"SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER"},
+	"sequence": {"USAGE", "SELECT", "UPDATE"},
+	"function": {"EXECUTE"},
+	"type":     {"USAGE"},
+}
+
+// pgDefaultACLObjectTypes maps object types to pg_default_acl.defaclobjtype values
+var pgDefaultACLObjectTypes = map[string]string{
+	"table":    "r",
+	"sequence": "S",
+	"function": "f",
+	"type":     "T",
+}
+
+// pgCAs keeps the CA certificate of each project for the lifetime of the provider process
+var pgCAs sync.Map
+
+// pgProjectCA returns the CA certificate of the project, it is fetched once
+func pgProjectCA(client *aiven.Client, projectName string) (string, error) {
+	if v, ok := pgCAs.Load(projectName); ok {
+		return v.(string), nil
+	}
+
+	ca, err := client.CA.Get(projectName)
+	if err != nil {
+		return "", fmt.Errorf("cannot get project CA certificate: %w", err)
+	}
+
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(ca)) {
+		return "", fmt.Errorf("cannot parse project %s CA certificate", projectName)
+	}
+
+	pgCAs.Store(projectName, ca)
+	return ca, nil
+}
+
+// pgWriteCAFile writes a CA certificate to a temporary file, lib/pq reads sslrootcert
+// from a file whenever it opens a connection
+func pgWriteCAFile(ca string) (string, error) {
+	f, err := ioutil.TempFile("", "aiven-ca-*.pem")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = f.WriteString(ca)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("cannot write CA certificate: %w", err)
+	}
+
+	return f.Name(), nil
+}
+
+// pgServiceURI returns the connection URI of the admin user of a PostgreSQL service and
+// the CA certificate to verify the connection against, like mysqlServiceDSN does; tests
+// replace it to run the PostgreSQL resources against a local server
+var pgServiceURI = func(client *aiven.Client, projectName, serviceName string) (string, string, error) {
+	s, err := client.Services.Get(projectName, serviceName)
+	if err != nil {
+		return "", "", err
+	}
+
+	if s.Type != ServiceTypePG {
+		return "", "", fmt.Errorf("service %s/%s is of type %s, expected %s", projectName, serviceName, s.Type, ServiceTypePG)
+	}
+
+	u, err := url.Parse(s.URI)
+	if err != nil || u.Host == "" {
+		return "", "", fmt.Errorf("service %s/%s has no valid connection URI, is it running?", projectName, serviceName)
+	}
+
+	ca, err := pgProjectCA(client, projectName)
+	if err != nil {
+		return "", "", err
+	}
+
+	return u.String(), ca, nil
+}
+
+// pgConnect opens a connection to a database of a PostgreSQL service through its SQL endpoint;
+// the CA certificate file only exists while the connection is opened, so the returned pool
+// is limited to that single connection
+func pgConnect(ctx context.Context, client *aiven.Client, projectName, serviceName, database string) (*sql.DB, error) {
+	uri, ca, err := pgServiceURI(client, projectName, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse service connection URI: %w", err)
+	}
+	u.Path = "/" + database
+
+	if ca != "" {
+		caFile, err := pgWriteCAFile(ca)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(caFile)
+
+		q := u.Query()
+		q.Set("sslmode", "verify-full")
+		q.Set("sslrootcert", caFile)
+		u.RawQuery = q.Encode()
+	}
+
+	db, err := sql.Open("postgres", u.String())
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("cannot connect to database %s of service %s/%s: %w", database, projectName, serviceName, err)
+	}
+
+	return db, nil
+}
+
+// pgReadHandleNotFound removes a resource from the state when its service or database
+// no longer exists, like resourceReadHandleNotFound does for API resources
+func pgReadHandleNotFound(err error, d *schema.ResourceData) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "3D000" {
+		err = aiven.Error{Message: pqErr.Message, Status: http.StatusNotFound}
+	}
+
+	return resourceReadHandleNotFound(err, d)
+}
+
+// pgExec runs the statements in a single transaction
+func pgExec(ctx context.Context, db *sql.DB, statements ...string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range statements {
+		if _, err := tx.ExecContext(ctx, s); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("cannot execute `%s`: %w", s, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// validatePGPrivileges checks that the privileges can be granted on the object type
+func validatePGPrivileges(objectType string, privileges []string) error {
+	allowed, ok := pgObjectPrivileges[objectType]
+	if !ok {
+		return fmt.Errorf("unsupported object type `%s`", objectType)
+	}
+
+	for _, p := range privileges {
+		valid := false
+		for _, a := range allowed {
+			if p == a {
+				valid = true
+			}
+		}
+
+		if !valid {
+			return fmt.Errorf("privilege `%s` cannot be granted on a %s, expected one of %v", p, objectType, allowed)
+		}
+	}
+
+	return nil
+}
+
+// pgGrantTarget builds the object part of GRANT and REVOKE statements, an empty objects
+// list targets all objects of the type in the schema
+func pgGrantTarget(objectType, schemaName string, objects []string) string {
+	if objectType == "schema" {
+		return "SCHEMA " + pq.QuoteIdentifier(schemaName)
+	}
+
+	if len(objects) == 0 {
+		return fmt.Sprintf("ALL %sS IN SCHEMA %s", strings.ToUpper(objectType), pq.QuoteIdentifier(schemaName))
+	}
+
+	names := make([]string, len(objects))
+	for i, o := range objects {
+		names[i] = pq.QuoteIdentifier(schemaName) + "." + pq.QuoteIdentifier(o)
+	}
+
+	return fmt.Sprintf("%s %s", strings.ToUpper(objectType), strings.Join(names, ", "))
+}
+
+// pgGrantStatements builds the statements that replace the privileges of a role on the objects
+func pgGrantStatements(objectType, schemaName string, objects, privileges []string, role string) []string {
+	target := pgGrantTarget(objectType, schemaName, objects)
+	statements := []string{fmt.Sprintf("REVOKE ALL ON %s FROM %s", target, pq.QuoteIdentifier(role))}
+	if len(privileges) > 0 {
+		statements = append(statements, fmt.Sprintf("GRANT %s ON %s TO %s",
+			strings.Join(privileges, ", "), target, pq.QuoteIdentifier(role)))
+	}
+
+	return statements
+}
+
+// pgDefaultPrivilegesStatements builds the statements that replace the privileges a role
+// gets on objects the owner creates in the future; an empty schema applies to all schemas
+func pgDefaultPrivilegesStatements(owner, schemaName, objectType string, privileges []string, role string) []string {
+	prefix := "ALTER DEFAULT PRIVILEGES FOR ROLE " + pq.QuoteIdentifier(owner)
+	if schemaName != "" {
+		prefix += " IN SCHEMA " + pq.QuoteIdentifier(schemaName)
+	}
+	objects := strings.ToUpper(objectType) + "S"
+
+	statements := []string{fmt.Sprintf("%s REVOKE ALL ON %s FROM %s", prefix, objects, pq.QuoteIdentifier(role))}
+	if len(privileges) > 0 {
+		statements = append(statements, fmt.Sprintf("%s GRANT %s ON %s TO %s",
+			prefix, strings.Join(privileges, ", "), objects, pq.QuoteIdentifier(role)))
+	}
+
+	return statements
+}
+
+// pgGrantObjectsQuery returns a query listing the objects of a type in a schema together
+// with their ACL; the query takes the schema name as its only parameter
+func pgGrantObjectsQuery(objectType string) string {
+	switch objectType {
+	case "schema":
+		return `SELECT n.nspname, n.nspacl FROM pg_namespace n WHERE n.nspname = $1`
+	case "function":
+		return `SELECT p.proname, p.proacl FROM pg_proc p
+			JOIN pg_namespace n ON n.oid = p.pronamespace WHERE n.nspname = $1`
+	case "sequence":
+		return `SELECT c.relname, c.relacl FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace WHERE n.nspname = $1 AND c.relkind = 'S'`
+	default:
+		return `SELECT c.relname, c.relacl FROM pg_class c
+			JOIN pg_namespace n ON n.oid = c.relnamespace WHERE n.nspname = $1 AND c.relkind IN ('r', 'v', 'm', 'f', 'p')`
+	}
+}
+
+// readPGGrants returns the privileges the role holds on every one of the objects, the
+// second return value is false when none of the objects exist
+func readPGGrants(ctx context.Context, db *sql.DB, objectType, schemaName string, objects []string, role string) ([]string, bool, error) {
+	query := fmt.Sprintf(`SELECT o.name, a.privilege_type
+		FROM (%s) o(name, acl)
+		LEFT JOIN LATERAL (
+			SELECT e.privilege_type FROM aclexplode(o.acl) e
+			JOIN pg_roles r ON r.oid = e.grantee WHERE r.rolname = $2
+		) a ON true`, pgGrantObjectsQuery(objectType))
+
+	rows, err := db.QueryContext(ctx, query, schemaName, role)
+	if err != nil {
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	wanted := make(map[string]bool)
+	for _, o := range objects {
+		wanted[o] = true
+	}
+
+	grants := make(map[string]map[string]bool)
+	for rows.Next() {
+		var name string
+		var privilege sql.NullString
+		if err := rows.Scan(&name, &privilege); err != nil {
+			return nil, false, err
+		}
+
+		if len(wanted) > 0 && !wanted[name] {
+			continue
+		}
+
+		if grants[name] == nil {
+			grants[name] = make(map[string]bool)
+		}
+		if privilege.Valid {
+			grants[name][privilege.String] = true
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, false, err
+	}
+
+	privileges, ok := commonPGPrivileges(grants)
+	return privileges, ok, nil
+}
+
+// commonPGPrivileges returns the sorted privileges present on every object, the second
+// return value is false when there are no objects
+func commonPGPrivileges(grants map[string]map[string]bool) ([]string, bool) {
+	if len(grants) == 0 {
+		return nil, false
+	}
+
+	counts := make(map[string]int)
+	for _, privileges := range grants {
+		for p := range privileges {
+			counts[p]++
+		}
+	}
+
+	var common []string
+	for p, c := range counts {
+		if c == len(grants) {
+			common = append(common, p)
+		}
+	}
+	sort.Strings(common)
+
+	return common, true
+}
+
+// readPGDefaultPrivileges returns the privileges the role gets on objects of the type
+// the owner creates in the schema
+func readPGDefaultPrivileges(ctx context.Context, db *sql.DB, owner, schemaName, objectType, role string) ([]string, error) {
+	rows, err := db.QueryContext(ctx, `SELECT a.privilege_type
+		FROM pg_default_acl d
+		JOIN pg_roles o ON o.oid = d.defaclrole
+		LEFT JOIN pg_namespace n ON n.oid = d.defaclnamespace
+		CROSS JOIN LATERAL aclexplode(d.defaclacl) a
+		JOIN pg_roles r ON r.oid = a.grantee
+		WHERE o.rolname = $1 AND COALESCE(n.nspname, '') = $2 AND d.defaclobjtype = $3 AND r.rolname = $4`,
+		owner, schemaName, pgDefaultACLObjectTypes[objectType], role)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var privileges []string
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return nil, err
+		}
+		privileges = append(privileges, p)
+	}
+	sort.Strings(privileges)
+
+	return privileges, rows.Err()
+}
@@ -0,0 +1,407 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// privatelinkSchema adds the project and service name shared by the privatelink
+// resources of all clouds to the cloud specific schema
+func privatelinkSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["project"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Project name",
+		ForceNew:    true,
+	}
+	s["service_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Service name",
+		ForceNew:    true,
+	}
+
+	return s
+}
+
+// PrivatelinkWaiter is used to wait for Aiven to build the privatelink of a service in any cloud
+type PrivatelinkWaiter struct {
+	// Cloud is used in log messages only
+	Cloud string
+	// Get returns the privatelink and its state
+	Get func() (interface{}, string, error)
+}
+
+// RefreshFunc will call the Aiven client and refresh it's state.
+func (w *PrivatelinkWaiter) RefreshFunc() resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		p, state, err := w.Get()
+		if err != nil {
+			return nil, "", err
+		}
+
+		log.Printf("[DEBUG] Got %s state while waiting for %s privatelink to be active.", state, w.Cloud)
+
+		return p, state, nil
+	}
+}
+
+// Conf sets up the configuration to refresh.
+func (w *PrivatelinkWaiter) Conf(timeout time.Duration) *resource.StateChangeConf {
+	log.Printf("[DEBUG] Create waiter timeout %.0f minutes", timeout.Minutes())
+
+	return &resource.StateChangeConf{
+		Pending: []string{"creating"},
+		Target:  []string{"active"},
+		Refresh: w.RefreshFunc(),
+		Delay:   10 * time.Second,
+		Timeout: timeout,
+	}
+}
+
+// privatelinkPath builds the API path of the privatelink of a service in a cloud,
+// aiven-go-client v1.6.1 only covers AWS
+func privatelinkPath(project, serviceName, cloud string, parts ...string) string {
+	return aivenAPIPath(append([]string{"project", project, "service", serviceName, "privatelink", cloud}, parts...)...)
+}
+
+// privatelinkImportState reads the privatelink of an imported resource
+func privatelinkImportState(read schema.ReadContextFunc, cloud string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		di := read(ctx, d, m)
+		if di.HasError() {
+			return nil, fmt.Errorf("cannot get %s privatelink %v", cloud, di)
+		}
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// privatelinkDatasourceRead reads the privatelink of the service given in the data source
+func privatelinkDatasourceRead(read schema.ReadContextFunc) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		projectName := d.Get("project").(string)
+		serviceName := d.Get("service_name").(string)
+		d.SetId(buildResourceID(projectName, serviceName))
+
+		return read(ctx, d, m)
+	}
+}
+
+// privatelinkConnection is a connection from a private endpoint of the user to the
+// privatelink of a service in Azure or Google Cloud
+type privatelinkConnection struct {
+	PrivatelinkConnectionID string `json:"privatelink_connection_id"`
+	State                   string `json:"state"`
+	UserIPAddress           string `json:"user_ip_address"`
+	// PrivateEndpointID is Azure private endpoint resource ID
+	PrivateEndpointID string `json:"private_endpoint_id,omitempty"`
+	// PSCConnectionID is Google Cloud Private Service Connect connection ID
+	PSCConnectionID string `json:"psc_connection_id,omitempty"`
+}
+
+type privatelinkConnectionsResponse struct {
+	aiven.APIResponse
+	Connections []*privatelinkConnection `json:"connections"`
+}
+
+type privatelinkConnectionIPRequest struct {
+	UserIPAddress string `json:"user_ip_address"`
+}
+
+// privatelinkCloud describes how connections of a privatelink are approved in a cloud
+type privatelinkCloud struct {
+	// name is the cloud in the API paths, `azure` or `google`
+	name string
+	// title is the cloud in descriptions and messages
+	title string
+	// endpointKey is the schema key of the cloud identifier of the user's endpoint
+	endpointKey         string
+	endpointDescription string
+	// approveWithIP sends the IP address of the endpoint with the approval, otherwise
+	// it is set by updating the connection after the approval
+	approveWithIP bool
+}
+
+func (c privatelinkCloud) endpointID(conn *privatelinkConnection) string {
+	if c.name == "azure" {
+		return conn.PrivateEndpointID
+	}
+
+	return conn.PSCConnectionID
+}
+
+func listPrivatelinkConnections(
+	ctx context.Context,
+	client *aiven.Client,
+	cloud privatelinkCloud,
+	project, serviceName string,
+) ([]*privatelinkConnection, error) {
+	var r privatelinkConnectionsResponse
+	err := aivenAPIRequest(ctx, client, http.MethodGet, privatelinkPath(project, serviceName, cloud.name, "connections"), nil, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Connections, nil
+}
+
+// findPrivatelinkConnection finds the connection of the endpoint, an empty endpoint ID
+// matches the only connection of the privatelink
+func findPrivatelinkConnection(cloud privatelinkCloud, connections []*privatelinkConnection, endpointID string) (*privatelinkConnection, error) {
+	var found []*privatelinkConnection
+	for _, conn := range connections {
+		if endpointID == "" || cloud.endpointID(conn) == endpointID {
+			found = append(found, conn)
+		}
+	}
+
+	switch {
+	case len(found) == 0:
+		return nil, nil
+	case len(found) > 1:
+		return nil, fmt.Errorf("the %s privatelink has %d connections, set `%s` to choose one", cloud.title, len(found), cloud.endpointKey)
+	}
+
+	return found[0], nil
+}
+
+func resourcePrivatelinkConnectionApproval(cloud privatelinkCloud) *schema.Resource {
+	// Google Cloud takes the IP address with the approval only, it cannot be updated
+	var update schema.UpdateContextFunc
+	if !cloud.approveWithIP {
+		update = resourcePrivatelinkConnectionApprovalUpdate(cloud)
+	}
+
+	return &schema.Resource{
+		Description:   fmt.Sprintf("Approves the connection of a private endpoint to the %s privatelink of a service", cloud.title),
+		CreateContext: resourcePrivatelinkConnectionApprovalCreate(cloud),
+		ReadContext:   resourcePrivatelinkConnectionApprovalRead(cloud),
+		UpdateContext: update,
+		DeleteContext: resourcePrivatelinkConnectionApprovalDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: privatelinkImportState(resourcePrivatelinkConnectionApprovalRead(cloud), cloud.title),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: privatelinkSchema(map[string]*schema.Schema{
+			"user_ip_address": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    cloud.approveWithIP,
+				Description: "Private IP address of the endpoint",
+			},
+			cloud.endpointKey: {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: cloud.endpointDescription + ", required when the privatelink has several connections",
+			},
+			"privatelink_connection_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Privatelink connection ID",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the privatelink connection",
+			},
+		}),
+	}
+}
+
+func resourcePrivatelinkConnectionApprovalCreate(cloud privatelinkCloud) schema.CreateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		client := m.(*providerMeta).client
+		project := d.Get("project").(string)
+		serviceName := d.Get("service_name").(string)
+		endpointID := d.Get(cloud.endpointKey).(string)
+		ip := d.Get("user_ip_address").(string)
+
+		// Aiven only sees new endpoints of the cloud after a refresh
+		err := aivenAPIRequest(ctx, client, http.MethodPost, privatelinkPath(project, serviceName, cloud.name, "connections", "refresh"), nil, nil)
+		if err != nil {
+			return diag.Errorf("cannot refresh %s privatelink connections: %s", cloud.title, err)
+		}
+
+		w := &PrivatelinkConnectionWaiter{
+			Context:     ctx,
+			Client:      client,
+			Cloud:       cloud,
+			Project:     project,
+			ServiceName: serviceName,
+			EndpointID:  endpointID,
+		}
+
+		res, err := w.Conf(d.Timeout(schema.TimeoutCreate), []string{""},
+			[]string{"pending-user-approval", "user-approved", "connected", "active"}).WaitForStateContext(ctx)
+		if err != nil {
+			return diag.Errorf("error waiting for %s privatelink connection: %s", cloud.title, err)
+		}
+		conn := res.(*privatelinkConnection)
+		w.EndpointID = cloud.endpointID(conn)
+
+		if conn.State == "pending-user-approval" {
+			var body interface{}
+			if cloud.approveWithIP {
+				body = privatelinkConnectionIPRequest{UserIPAddress: ip}
+			}
+
+			err := aivenAPIRequest(ctx, client, http.MethodPost,
+				privatelinkPath(project, serviceName, cloud.name, "connections", conn.PrivatelinkConnectionID, "approve"), body, nil)
+			if err != nil {
+				return diag.Errorf("cannot approve %s privatelink connection: %s", cloud.title, err)
+			}
+		}
+
+		if !cloud.approveWithIP {
+			if err := updatePrivatelinkConnectionIP(ctx, client, cloud, project, serviceName, conn.PrivatelinkConnectionID, ip); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		d.SetId(buildResourceID(project, serviceName, conn.PrivatelinkConnectionID))
+
+		if _, err := w.Conf(d.Timeout(schema.TimeoutCreate), []string{"pending-user-approval", "user-approved", "connected"},
+			[]string{"active"}).WaitForStateContext(ctx); err != nil {
+			return diag.Errorf("error waiting for %s privatelink connection to be active: %s", cloud.title, err)
+		}
+
+		return resourcePrivatelinkConnectionApprovalRead(cloud)(ctx, d, m)
+	}
+}
+
+func updatePrivatelinkConnectionIP(
+	ctx context.Context,
+	client *aiven.Client,
+	cloud privatelinkCloud,
+	project, serviceName, connectionID, ip string,
+) error {
+	err := aivenAPIRequest(ctx, client, http.MethodPut,
+		privatelinkPath(project, serviceName, cloud.name, "connections", connectionID),
+		privatelinkConnectionIPRequest{UserIPAddress: ip}, nil)
+	if err != nil {
+		return fmt.Errorf("cannot set the IP address of %s privatelink connection: %w", cloud.title, err)
+	}
+
+	return nil
+}
+
+func resourcePrivatelinkConnectionApprovalRead(cloud privatelinkCloud) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		client := m.(*providerMeta).client
+		project, serviceName, connectionID := splitResourceID3(d.Id())
+
+		connections, err := listPrivatelinkConnections(ctx, client, cloud, project, serviceName)
+		if err != nil {
+			return diag.FromErr(resourceReadHandleNotFound(err, d))
+		}
+
+		var conn *privatelinkConnection
+		for _, c := range connections {
+			if c.PrivatelinkConnectionID == connectionID {
+				conn = c
+			}
+		}
+		if conn == nil {
+			log.Printf("[DEBUG] %s privatelink connection %s is gone", cloud.title, connectionID)
+			d.SetId("")
+			return nil
+		}
+
+		if err := d.Set("project", project); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("service_name", serviceName); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("user_ip_address", conn.UserIPAddress); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set(cloud.endpointKey, cloud.endpointID(conn)); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("privatelink_connection_id", conn.PrivatelinkConnectionID); err != nil {
+			return diag.FromErr(err)
+		}
+		if err := d.Set("state", conn.State); err != nil {
+			return diag.FromErr(err)
+		}
+
+		return nil
+	}
+}
+
+func resourcePrivatelinkConnectionApprovalUpdate(cloud privatelinkCloud) schema.UpdateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		project, serviceName, connectionID := splitResourceID3(d.Id())
+
+		err := updatePrivatelinkConnectionIP(ctx, m.(*providerMeta).client, cloud, project, serviceName, connectionID,
+			d.Get("user_ip_address").(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		return resourcePrivatelinkConnectionApprovalRead(cloud)(ctx, d, m)
+	}
+}
+
+// resourcePrivatelinkConnectionApprovalDelete only forgets the approval, a connection
+// goes away when the private endpoint is deleted in the cloud
+func resourcePrivatelinkConnectionApprovalDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// PrivatelinkConnectionWaiter is used to wait for a privatelink connection to show up and to become active
+type PrivatelinkConnectionWaiter struct {
+	Context     context.Context
+	Client      *aiven.Client
+	Cloud       privatelinkCloud
+	Project     string
+	ServiceName string
+	EndpointID  string
+}
+
+// RefreshFunc will call the Aiven client and refresh it's state.
+func (w *PrivatelinkConnectionWaiter) RefreshFunc() resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		connections, err := listPrivatelinkConnections(w.Context, w.Client, w.Cloud, w.Project, w.ServiceName)
+		if err != nil {
+			return nil, "", err
+		}
+
+		conn, err := findPrivatelinkConnection(w.Cloud, connections, w.EndpointID)
+		if err != nil || conn == nil {
+			return struct{}{}, "", err
+		}
+
+		log.Printf("[DEBUG] Got %s state of %s privatelink connection.", conn.State, w.Cloud.title)
+
+		return conn, conn.State, nil
+	}
+}
+
+// Conf sets up the configuration to refresh.
+func (w *PrivatelinkConnectionWaiter) Conf(timeout time.Duration, pending, target []string) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     target,
+		Refresh:    w.RefreshFunc(),
+		Timeout:    timeout,
+		MinTimeout: 5 * time.Second,
+	}
+}
@@ -0,0 +1,104 @@
+package aiven
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// fakePrivatelinkConnections is a minimal fake of the privatelink connection endpoints
+// of the Aiven API with a single connection of the given cloud
+type fakePrivatelinkConnections struct {
+	cloud     string
+	conn      *privatelinkConnection
+	refreshed bool
+	approval  privatelinkConnectionIPRequest
+}
+
+func (f *fakePrivatelinkConnections) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	base := "/project/test/service/kafka/privatelink/" + f.cloud + "/connections"
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == base+"/refresh":
+		f.refreshed = true
+	case r.Method == http.MethodGet && r.URL.Path == base:
+		var connections []*privatelinkConnection
+		if f.refreshed {
+			connections = append(connections, f.conn)
+		}
+		_ = json.NewEncoder(w).Encode(privatelinkConnectionsResponse{Connections: connections})
+	case r.Method == http.MethodPost && r.URL.Path == base+"/plc1/approve":
+		_ = json.NewDecoder(r.Body).Decode(&f.approval)
+		f.conn.State, f.conn.UserIPAddress = "active", f.approval.UserIPAddress
+	case r.Method == http.MethodPut && r.URL.Path == base+"/plc1":
+		var req privatelinkConnectionIPRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.conn.UserIPAddress = req.UserIPAddress
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestPrivatelinkConnectionApproval(t *testing.T) {
+	tests := []struct {
+		name  string
+		cloud privatelinkCloud
+		conn  privatelinkConnection
+	}{
+		{
+			"azure",
+			azurePrivatelinkCloud,
+			privatelinkConnection{PrivatelinkConnectionID: "plc1", State: "pending-user-approval", PrivateEndpointID: "/subscriptions/s/pe"},
+		},
+		{
+			"google",
+			gcpPrivatelinkCloud,
+			privatelinkConnection{PrivatelinkConnectionID: "plc1", State: "pending-user-approval", PSCConnectionID: "123"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := tt.conn
+			fake := &fakePrivatelinkConnections{cloud: tt.cloud.name, conn: &conn}
+			meta := newTestAivenAPI(t, fake)
+
+			r := resourcePrivatelinkConnectionApproval(tt.cloud)
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				"project":         "test",
+				"service_name":    "kafka",
+				"user_ip_address": "10.0.0.5",
+			})
+			if di := r.CreateContext(context.Background(), d, meta); di.HasError() {
+				t.Fatal(di)
+			}
+
+			if d.Id() != "test/kafka/plc1" || d.Get("state") != "active" || d.Get("user_ip_address") != "10.0.0.5" ||
+				d.Get(tt.cloud.endpointKey) != tt.cloud.endpointID(&tt.conn) {
+				t.Errorf("unexpected connection %s %v %v %v", d.Id(), d.Get("state"), d.Get("user_ip_address"), d.Get(tt.cloud.endpointKey))
+			}
+
+			if tt.cloud.approveWithIP != (fake.approval.UserIPAddress == "10.0.0.5") {
+				t.Errorf("unexpected approval request %v", fake.approval)
+			}
+		})
+	}
+}
+
+func Test_findPrivatelinkConnection(t *testing.T) {
+	connections := []*privatelinkConnection{
+		{PrivatelinkConnectionID: "plc1", PSCConnectionID: "1"},
+		{PrivatelinkConnectionID: "plc2", PSCConnectionID: "2"},
+	}
+
+	if c, err := findPrivatelinkConnection(gcpPrivatelinkCloud, connections, "2"); err != nil || c.PrivatelinkConnectionID != "plc2" {
+		t.Errorf("unexpected connection %v %v", c, err)
+	}
+	if _, err := findPrivatelinkConnection(gcpPrivatelinkCloud, connections, ""); err == nil {
+		t.Error("expected an error for several connections without an endpoint")
+	}
+	if c, err := findPrivatelinkConnection(gcpPrivatelinkCloud, connections, "3"); err != nil || c != nil {
+		t.Errorf("expected no connection, got %v %v", c, err)
+	}
+}
@@ -7,14 +7,17 @@ import (
 	"fmt"
 	"log"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/aiven/terraform-provider-aiven/pkg/cache"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Provider returns a terraform.ResourceProvider.
@@ -28,6 +31,28 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("AIVEN_TOKEN", nil),
 				Description: "Aiven Authentication Token",
 			},
+			"max_monthly_cost_per_service": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Description:  "Fail the plan of a service whose plan costs more USD a month",
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"default_tags": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Tags added to every project and service resource",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"tags": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Tags, the tag block of a resource overrides tags with the same key",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
@@ -41,6 +66,8 @@ func Provider() *schema.Provider {
 			"aiven_project":                        datasourceProject(),
 			"aiven_project_user":                   datasourceProjectUser(),
 			"aiven_project_vpc":                    datasourceProjectVPC(),
+			"aiven_project_vpcs":                   datasourceProjectVPCs(),
+			"aiven_project_event_log":              datasourceProjectEventLog(),
 			"aiven_vpc_peering_connection":         datasourceVPCPeeringConnection(),
 			"aiven_service":                        datasourceService(),
 			"aiven_service_integration":            datasourceServiceIntegration(),
@@ -49,6 +76,7 @@ func Provider() *schema.Provider {
 			"aiven_account":                        datasourceAccount(),
 			"aiven_account_team":                   datasourceAccountTeam(),
 			"aiven_account_team_project":           datasourceAccountTeamProject(),
+			"aiven_account_projects":               datasourceAccountProjects(),
 			"aiven_account_team_member":            datasourceAccountTeamMember(),
 			"aiven_mirrormaker_replication_flow":   datasourceMirrorMakerReplicationFlowTopic(),
 			"aiven_account_authentication":         datasourceAccountAuthentication(),
@@ -66,59 +94,81 @@ func Provider() *schema.Provider {
 			"aiven_redis":                          datasourceRedis(),
 			"aiven_transit_gateway_vpc_attachment": datasourceTransitGatewayVPCAttachment(),
 			"aiven_service_component":              datasourceServiceComponent(),
+			"aiven_service_backups":                datasourceServiceBackups(),
 			"aiven_m3db":                           datasourceM3DB(),
 			"aiven_m3aggregator":                   datasourceM3Aggregator(),
 			"aiven_aws_privatelink":                datasourceAWSPrivatelink(),
+			"aiven_azure_privatelink":              datasourceAzurePrivatelink(),
+			"aiven_gcp_privatelink":                datasourceGCPPrivatelink(),
+			"aiven_static_ips":                     datasourceStaticIPs(),
 			"aiven_opensearch":                     datasourceOpensearch(),
 			"aiven_opensearch_acl_config":          datasourceOpensearchACLConfig(),
 			"aiven_opensearch_acl_rule":            datasourceOpensearchACLRule(),
+			"aiven_billing_group_costs":            datasourceBillingGroupCosts(),
+			"aiven_billing_group_credits":          datasourceBillingGroupCredits(),
+			"aiven_billing_group_invoices":         datasourceBillingGroupInvoices(),
 
 			// deprecated
 			"aiven_elasticsearch_acl": datasourceElasticsearchACL(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"aiven_connection_pool":                resourceConnectionPool(),
-			"aiven_database":                       resourceDatabase(),
-			"aiven_kafka_acl":                      resourceKafkaACL(),
-			"aiven_kafka_topic":                    resourceKafkaTopic(),
-			"aiven_kafka_connector":                resourceKafkaConnector(),
-			"aiven_kafka_schema":                   resourceKafkaSchema(),
-			"aiven_kafka_schema_configuration":     resourceKafkaSchemaConfiguration(),
-			"aiven_project":                        resourceProject(),
-			"aiven_project_user":                   resourceProjectUser(),
-			"aiven_project_vpc":                    resourceProjectVPC(),
-			"aiven_vpc_peering_connection":         resourceVPCPeeringConnection(),
-			"aiven_service":                        resourceService(),
-			"aiven_service_integration":            resourceServiceIntegration(),
-			"aiven_service_integration_endpoint":   resourceServiceIntegrationEndpoint(),
-			"aiven_service_user":                   resourceServiceUser(),
-			"aiven_account":                        resourceAccount(),
-			"aiven_account_team":                   resourceAccountTeam(),
-			"aiven_account_team_project":           resourceAccountTeamProject(),
-			"aiven_account_team_member":            resourceAccountTeamMember(),
-			"aiven_mirrormaker_replication_flow":   resourceMirrorMakerReplicationFlow(),
-			"aiven_account_authentication":         resourceAccountAuthentication(),
-			"aiven_kafka":                          resourceKafka(),
-			"aiven_kafka_connect":                  resourceKafkaConnect(),
-			"aiven_kafka_mirrormaker":              resourceKafkaMirrormaker(),
-			"aiven_pg":                             resourcePG(),
-			"aiven_mysql":                          resourceMySQL(),
-			"aiven_cassandra":                      resourceCassandra(),
-			"aiven_elasticsearch":                  resourceElasticsearch(),
-			"aiven_elasticsearch_acl_config":       resourceElasticsearchACLConfig(),
-			"aiven_elasticsearch_acl_rule":         resourceElasticsearchACLRule(),
-			"aiven_grafana":                        resourceGrafana(),
-			"aiven_influxdb":                       resourceInfluxDB(),
-			"aiven_redis":                          resourceRedis(),
-			"aiven_transit_gateway_vpc_attachment": resourceTransitGatewayVPCAttachment(),
-			"aiven_m3db":                           resourceM3DB(),
-			"aiven_m3aggregator":                   resourceM3Aggregator(),
-			"aiven_billing_group":                  resourceBillingGroup(),
-			"aiven_aws_privatelink":                resourceAWSPrivatelink(),
-			"aiven_opensearch":                     resourceOpensearch(),
-			"aiven_opensearch_acl_config":          resourceOpensearchACLConfig(),
-			"aiven_opensearch_acl_rule":            resourceOpensearchACLRule(),
+			"aiven_connection_pool":                       resourceConnectionPool(),
+			"aiven_database":                              resourceDatabase(),
+			"aiven_kafka_acl":                             resourceKafkaACL(),
+			"aiven_kafka_topic":                           resourceKafkaTopic(),
+			"aiven_kafka_connector":                       resourceKafkaConnector(),
+			"aiven_kafka_schema":                          resourceKafkaSchema(),
+			"aiven_kafka_schema_configuration":            resourceKafkaSchemaConfiguration(),
+			"aiven_project":                               resourceProject(),
+			"aiven_project_user":                          resourceProjectUser(),
+			"aiven_project_users":                         resourceProjectUsers(),
+			"aiven_project_vpc":                           resourceProjectVPC(),
+			"aiven_vpc_peering_connection":                resourceVPCPeeringConnection(),
+			"aiven_service":                               resourceService(),
+			"aiven_service_integration":                   resourceServiceIntegration(),
+			"aiven_service_integration_endpoint":          resourceServiceIntegrationEndpoint(),
+			"aiven_service_user":                          resourceServiceUser(),
+			"aiven_account":                               resourceAccount(),
+			"aiven_account_team":                          resourceAccountTeam(),
+			"aiven_account_team_project":                  resourceAccountTeamProject(),
+			"aiven_account_team_projects":                 resourceAccountTeamProjects(),
+			"aiven_account_team_member":                   resourceAccountTeamMember(),
+			"aiven_account_team_members":                  resourceAccountTeamMembers(),
+			"aiven_mirrormaker_replication_flow":          resourceMirrorMakerReplicationFlow(),
+			"aiven_account_authentication":                resourceAccountAuthentication(),
+			"aiven_kafka":                                 resourceKafka(),
+			"aiven_kafka_connect":                         resourceKafkaConnect(),
+			"aiven_kafka_mirrormaker":                     resourceKafkaMirrormaker(),
+			"aiven_pg":                                    resourcePG(),
+			"aiven_pg_extension":                          resourcePGExtension(),
+			"aiven_pg_grant":                              resourcePGGrant(),
+			"aiven_pg_default_privileges":                 resourcePGDefaultPrivileges(),
+			"aiven_mysql":                                 resourceMySQL(),
+			"aiven_mysql_grant":                           resourceMySQLGrant(),
+			"aiven_cassandra":                             resourceCassandra(),
+			"aiven_elasticsearch":                         resourceElasticsearch(),
+			"aiven_elasticsearch_acl_config":              resourceElasticsearchACLConfig(),
+			"aiven_elasticsearch_acl_rule":                resourceElasticsearchACLRule(),
+			"aiven_grafana":                               resourceGrafana(),
+			"aiven_influxdb":                              resourceInfluxDB(),
+			"aiven_redis":                                 resourceRedis(),
+			"aiven_transit_gateway_vpc_attachment":        resourceTransitGatewayVPCAttachment(),
+			"aiven_m3db":                                  resourceM3DB(),
+			"aiven_m3aggregator":                          resourceM3Aggregator(),
+			"aiven_billing_group":                         resourceBillingGroup(),
+			"aiven_aws_privatelink":                       resourceAWSPrivatelink(),
+			"aiven_azure_privatelink":                     resourceAzurePrivatelink(),
+			"aiven_azure_privatelink_connection_approval": resourcePrivatelinkConnectionApproval(azurePrivatelinkCloud),
+			"aiven_gcp_privatelink":                       resourceGCPPrivatelink(),
+			"aiven_gcp_privatelink_connection_approval":   resourcePrivatelinkConnectionApproval(gcpPrivatelinkCloud),
+			"aiven_static_ip":                             resourceStaticIP(),
+			"aiven_opensearch":                            resourceOpensearch(),
+			"aiven_opensearch_acl_config":                 resourceOpensearchACLConfig(),
+			"aiven_opensearch_acl_rule":                   resourceOpensearchACLRule(),
+			"aiven_opensearch_security_role":              resourceOpensearchSecurityRole(),
+			"aiven_opensearch_security_role_mapping":      resourceOpensearchSecurityRoleMapping(),
+			"aiven_opensearch_security_action_group":      resourceOpensearchSecurityActionGroup(),
 
 			// deprecated
 			"aiven_elasticsearch_acl": resourceElasticsearchACL(),
@@ -141,12 +191,35 @@ func Provider() *schema.Provider {
 			return nil, diag.FromErr(err)
 		}
 
-		return client, nil
+		defaultTags := make(map[string]string)
+		if v, ok := d.GetOk("default_tags.0.tags"); ok {
+			for k, v := range v.(map[string]interface{}) {
+				defaultTags[k] = v.(string)
+			}
+		}
+
+		return &providerMeta{
+			client:                   client,
+			maxMonthlyCostPerService: d.Get("max_monthly_cost_per_service").(float64),
+			defaultTags:              defaultTags,
+		}, nil
 	}
 
 	return p
 }
 
+// providerMeta is the meta value resources get, it holds the client and the provider
+// arguments resources need besides it
+type providerMeta struct {
+	client                   *aiven.Client
+	maxMonthlyCostPerService float64
+	defaultTags              map[string]string
+
+	// servicePricing keeps the service types of each project for the lifetime of the
+	// provider, a plan checks the price of every service of the project
+	servicePricing sync.Map
+}
+
 func optionalString(d *schema.ResourceData, key string) string {
 	str, ok := d.Get(key).(string)
 	if !ok {
@@ -368,6 +441,50 @@ func flattenToString(a []interface{}) []string {
 	return r
 }
 
+// stringMapChanges lists the keys to create, update and delete to turn one string map
+// into another, each list is sorted
+type stringMapChanges struct {
+	create []string
+	update []string
+	delete []string
+}
+
+// diffStringMaps compares the current key value pairs of an authoritative resource with
+// the desired ones; keys missing from current are created, keys missing from desired are
+// deleted and keys with a different value are updated
+func diffStringMaps(current, desired map[string]string) stringMapChanges {
+	var changes stringMapChanges
+	for k, v := range current {
+		n, ok := desired[k]
+		switch {
+		case !ok:
+			changes.delete = append(changes.delete, k)
+		case n != v:
+			changes.update = append(changes.update, k)
+		}
+	}
+	for k := range desired {
+		if _, ok := current[k]; !ok {
+			changes.create = append(changes.create, k)
+		}
+	}
+
+	sort.Strings(changes.create)
+	sort.Strings(changes.update)
+	sort.Strings(changes.delete)
+
+	return changes
+}
+
+// optionalTimeString formats a time that may be missing from the API response
+func optionalTimeString(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.String()
+}
+
 func resourceReadHandleNotFound(err error, d *schema.ResourceData) error {
 	if err != nil && aiven.IsNotFound(err) {
 		d.SetId("")
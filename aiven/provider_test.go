@@ -92,3 +92,42 @@ func Test_validateDurationString(t *testing.T) {
 		})
 	}
 }
+
+func Test_diffStringMaps(t *testing.T) {
+	tests := []struct {
+		name    string
+		current map[string]string
+		desired map[string]string
+		want    stringMapChanges
+	}{
+		{
+			"empty",
+			nil,
+			nil,
+			stringMapChanges{},
+		},
+		{
+			"create-update-delete",
+			map[string]string{"kept": "admin", "changed": "read_only", "removed": "developer"},
+			map[string]string{"kept": "admin", "changed": "operator", "new-b": "developer", "new-a": "read_only"},
+			stringMapChanges{
+				create: []string{"new-a", "new-b"},
+				update: []string{"changed"},
+				delete: []string{"removed"},
+			},
+		},
+		{
+			"delete-all",
+			map[string]string{"b": "", "a": ""},
+			nil,
+			stringMapChanges{delete: []string{"a", "b"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffStringMaps(tt.current, tt.desired); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffStringMaps() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -61,7 +61,7 @@ func resourceAccount() *schema.Resource {
 }
 
 func resourceAccountCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	name := d.Get("name").(string)
 
 	r, err := client.Accounts.Create(
@@ -79,7 +79,7 @@ func resourceAccountCreate(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceAccountRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	r, err := client.Accounts.Get(d.Id())
 	if err != nil {
@@ -109,7 +109,7 @@ func resourceAccountRead(_ context.Context, d *schema.ResourceData, m interface{
 }
 
 func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	r, err := client.Accounts.Update(d.Id(), aiven.Account{
 		Name: d.Get("name").(string),
@@ -124,7 +124,7 @@ func resourceAccountUpdate(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceAccountDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	err := client.Accounts.Delete(d.Id())
 	if err != nil && !aiven.IsNotFound(err) {
@@ -48,6 +48,31 @@ var aivenAccountAuthenticationSchema = map[string]*schema.Schema{
 		Description: "SAML Entity id",
 		Optional:    true,
 	},
+	"auto_join_team_id": {
+		Type:        schema.TypeString,
+		Description: "Team users are automatically added to when they log in with this authentication method",
+		Optional:    true,
+	},
+	"saml_certificate_issuer": {
+		Type:        schema.TypeString,
+		Description: "SAML Certificate issuer",
+		Computed:    true,
+	},
+	"saml_certificate_subject": {
+		Type:        schema.TypeString,
+		Description: "SAML Certificate subject",
+		Computed:    true,
+	},
+	"saml_certificate_not_valid_after": {
+		Type:        schema.TypeString,
+		Description: "SAML Certificate expiration time",
+		Computed:    true,
+	},
+	"saml_certificate_not_valid_before": {
+		Type:        schema.TypeString,
+		Description: "SAML Certificate validity start time",
+		Computed:    true,
+	},
 	"saml_acs_url": {
 		Type:        schema.TypeString,
 		Description: "SAML Assertion Consumer Service URL",
@@ -95,7 +120,7 @@ func resourceAccountAuthentication() *schema.Resource {
 }
 
 func resourceAccountAuthenticationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId := d.Get("account_id").(string)
 
@@ -108,6 +133,7 @@ func resourceAccountAuthenticationCreate(ctx context.Context, d *schema.Resource
 			SAMLCertificate: d.Get("saml_certificate").(string),
 			SAMLIdpUrl:      d.Get("saml_idp_url").(string),
 			SAMLEntity:      d.Get("saml_entity_id").(string),
+			AutoJoinTeamId:  d.Get("auto_join_team_id").(string),
 		},
 	)
 	if err != nil {
@@ -122,7 +148,7 @@ func resourceAccountAuthenticationCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceAccountAuthenticationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, authId := splitResourceID2(d.Id())
 	r, err := client.AccountAuthentications.Get(accountId, authId)
@@ -145,10 +171,25 @@ func resourceAccountAuthenticationRead(_ context.Context, d *schema.ResourceData
 	if err := d.Set("saml_certificate", r.AuthenticationMethod.SAMLCertificate); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("saml_idp_url", r.AuthenticationMethod.SAMLCertificate); err != nil {
+	if err := d.Set("saml_idp_url", r.AuthenticationMethod.SAMLIdpUrl); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("saml_entity_id", r.AuthenticationMethod.SAMLEntity); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("auto_join_team_id", r.AuthenticationMethod.AutoJoinTeamId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("saml_certificate_issuer", r.AuthenticationMethod.SAMLCertificateIssuer); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("saml_certificate_subject", r.AuthenticationMethod.SAMLCertificateSubject); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("saml_certificate_not_valid_after", optionalTimeString(r.AuthenticationMethod.SAMLCertificateNotValidAfter)); err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("saml_entity_id", r.AuthenticationMethod.SAMLCertificate); err != nil {
+	if err := d.Set("saml_certificate_not_valid_before", optionalTimeString(r.AuthenticationMethod.SAMLCertificateNotValidBefore)); err != nil {
 		return diag.FromErr(err)
 	}
 	if err := d.Set("authentication_id", r.AuthenticationMethod.Id); err != nil {
@@ -171,7 +212,7 @@ func resourceAccountAuthenticationRead(_ context.Context, d *schema.ResourceData
 }
 
 func resourceAccountAuthenticationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	accountId, authId := splitResourceID2(d.Id())
 
 	r, err := client.AccountAuthentications.Update(accountId, aiven.AccountAuthenticationMethod{
@@ -182,6 +223,7 @@ func resourceAccountAuthenticationUpdate(ctx context.Context, d *schema.Resource
 		SAMLCertificate: d.Get("saml_certificate").(string),
 		SAMLIdpUrl:      d.Get("saml_idp_url").(string),
 		SAMLEntity:      d.Get("saml_entity_id").(string),
+		AutoJoinTeamId:  d.Get("auto_join_team_id").(string),
 	})
 	if err != nil {
 		return diag.FromErr(err)
@@ -195,7 +237,7 @@ func resourceAccountAuthenticationUpdate(ctx context.Context, d *schema.Resource
 }
 
 func resourceAccountAuthenticationDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, teamId := splitResourceID2(d.Id())
 
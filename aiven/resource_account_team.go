@@ -54,7 +54,7 @@ func resourceAccountTeam() *schema.Resource {
 }
 
 func resourceAccountTeamCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	name := d.Get("name").(string)
 	accountId := d.Get("account_id").(string)
 
@@ -74,7 +74,7 @@ func resourceAccountTeamCreate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceAccountTeamRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, teamId := splitResourceID2(d.Id())
 	r, err := client.AccountTeams.Get(accountId, teamId)
@@ -102,7 +102,7 @@ func resourceAccountTeamRead(_ context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceAccountTeamUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	accountId, teamId := splitResourceID2(d.Id())
 
 	r, err := client.AccountTeams.Update(accountId, teamId, aiven.AccountTeam{
@@ -118,7 +118,7 @@ func resourceAccountTeamUpdate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceAccountTeamDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, teamId := splitResourceID2(d.Id())
 
@@ -64,7 +64,7 @@ func resourceAccountTeamMember() *schema.Resource {
 }
 
 func resourceAccountTeamMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	accountId := d.Get("account_id").(string)
 	teamId := d.Get("team_id").(string)
 	userEmail := d.Get("user_email").(string)
@@ -84,7 +84,7 @@ func resourceAccountTeamMemberCreate(ctx context.Context, d *schema.ResourceData
 
 func resourceAccountTeamMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var found bool
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	accountId, teamId, userEmail := splitResourceID3(d.Id())
 
 	r, err := client.AccountTeamInvites.List(accountId, teamId)
@@ -163,7 +163,7 @@ func resourceAccountTeamMemberRead(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceAccountTeamMemberDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, teamId, userEmail := splitResourceID3(d.Id())
 
@@ -0,0 +1,264 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var aivenAccountTeamMembersSchema = map[string]*schema.Schema{
+	"account_id": {
+		Type:        schema.TypeString,
+		Description: "Account id",
+		Required:    true,
+		ForceNew:    true,
+	},
+	"team_id": {
+		Type:        schema.TypeString,
+		Description: "Account team id",
+		Required:    true,
+		ForceNew:    true,
+	},
+	"user_emails": {
+		Type:        schema.TypeSet,
+		Description: "Emails of all team members, users missing from the list are removed from the team",
+		Required:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
+	"members": {
+		Type:        schema.TypeList,
+		Description: "Team members and pending invitations",
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"user_email": {
+					Type:        schema.TypeString,
+					Description: "User email",
+					Computed:    true,
+				},
+				"user_id": {
+					Type:        schema.TypeString,
+					Description: "User id, empty until the invitation is accepted",
+					Computed:    true,
+				},
+				"real_name": {
+					Type:        schema.TypeString,
+					Description: "User real name, empty until the invitation is accepted",
+					Computed:    true,
+				},
+				"invited_by_user_email": {
+					Type:        schema.TypeString,
+					Description: "Team invited by user email, empty once the invitation is accepted",
+					Computed:    true,
+				},
+				"accepted": {
+					Type:        schema.TypeBool,
+					Description: "Team member invitation status",
+					Computed:    true,
+				},
+				"create_time": {
+					Type:        schema.TypeString,
+					Description: "Time of creation",
+					Computed:    true,
+				},
+			},
+		},
+	},
+}
+
+func resourceAccountTeamMembers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAccountTeamMembersCreate,
+		ReadContext:   resourceAccountTeamMembersRead,
+		UpdateContext: resourceAccountTeamMembersUpdate,
+		DeleteContext: resourceAccountTeamMembersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenAccountTeamMembersSchema,
+	}
+}
+
+// accountTeamMembersChanges lists the actions needed to turn the current team members and
+// pending invitations into the desired set of user emails
+type accountTeamMembersChanges struct {
+	invite        []string
+	deleteMembers []aiven.AccountTeamMember
+	deleteInvites []string
+}
+
+func diffAccountTeamMembers(
+	emails []string,
+	members []aiven.AccountTeamMember,
+	invites []aiven.AccountTeamInvite,
+) accountTeamMembersChanges {
+	desired := make(map[string]string)
+	for _, e := range emails {
+		desired[e] = ""
+	}
+
+	current := make(map[string]string)
+	byEmail := make(map[string]aiven.AccountTeamMember)
+	for _, member := range members {
+		current[member.UserEmail] = ""
+		byEmail[member.UserEmail] = member
+	}
+	for _, invite := range invites {
+		current[invite.UserEmail] = ""
+	}
+
+	// expired invitations disappear from the invites list, such users are invited again
+	diff := diffStringMaps(current, desired)
+
+	changes := accountTeamMembersChanges{invite: diff.create}
+	for _, e := range diff.delete {
+		if member, ok := byEmail[e]; ok {
+			changes.deleteMembers = append(changes.deleteMembers, member)
+		} else {
+			changes.deleteInvites = append(changes.deleteInvites, e)
+		}
+	}
+
+	return changes
+}
+
+func resourceAccountTeamMembersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	accountId := d.Get("account_id").(string)
+	teamId := d.Get("team_id").(string)
+
+	if err := syncAccountTeamMembers(m.(*providerMeta).client, accountId, teamId, flattenToString(d.Get("user_emails").(*schema.Set).List())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(accountId, teamId))
+
+	return resourceAccountTeamMembersRead(ctx, d, m)
+}
+
+func resourceAccountTeamMembersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	accountId, teamId := splitResourceID2(d.Id())
+
+	if err := syncAccountTeamMembers(m.(*providerMeta).client, accountId, teamId, flattenToString(d.Get("user_emails").(*schema.Set).List())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAccountTeamMembersRead(ctx, d, m)
+}
+
+// syncAccountTeamMembers invites missing users and removes members and invitations
+// that are not in the emails list
+func syncAccountTeamMembers(client *aiven.Client, accountId, teamId string, emails []string) error {
+	rm, err := client.AccountTeamMembers.List(accountId, teamId)
+	if err != nil {
+		return err
+	}
+
+	ri, err := client.AccountTeamInvites.List(accountId, teamId)
+	if err != nil {
+		return err
+	}
+
+	changes := diffAccountTeamMembers(emails, rm.Members, ri.Invites)
+
+	for _, member := range changes.deleteMembers {
+		log.Printf("[DEBUG] removing account team member %s", member.UserEmail)
+		err := client.AccountTeamMembers.Delete(accountId, teamId, member.UserId)
+		if err != nil && !aiven.IsNotFound(err) {
+			return fmt.Errorf("cannot delete account team member %s: %w", member.UserEmail, err)
+		}
+	}
+
+	for _, email := range changes.deleteInvites {
+		log.Printf("[DEBUG] deleting account team invitation of %s", email)
+		err := client.AccountTeamInvites.Delete(accountId, teamId, email)
+		if err != nil && !aiven.IsNotFound(err) {
+			return fmt.Errorf("cannot delete account team invitation of %s: %w", email, err)
+		}
+	}
+
+	for _, email := range changes.invite {
+		log.Printf("[DEBUG] inviting %s to account team", email)
+		if err := client.AccountTeamMembers.Invite(accountId, teamId, email); err != nil {
+			return fmt.Errorf("cannot invite %s to account team: %w", email, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceAccountTeamMembersRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	accountId, teamId := splitResourceID2(d.Id())
+
+	rm, err := client.AccountTeamMembers.List(accountId, teamId)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	ri, err := client.AccountTeamInvites.List(accountId, teamId)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	var emails []string
+	var members []map[string]interface{}
+	accepted := make(map[string]bool)
+	for _, member := range rm.Members {
+		accepted[member.UserEmail] = true
+		emails = append(emails, member.UserEmail)
+		members = append(members, map[string]interface{}{
+			"user_email":            member.UserEmail,
+			"user_id":               member.UserId,
+			"real_name":             member.RealName,
+			"invited_by_user_email": "",
+			"accepted":              true,
+			"create_time":           optionalTimeString(member.CreateTime),
+		})
+	}
+
+	for _, invite := range ri.Invites {
+		if accepted[invite.UserEmail] {
+			continue
+		}
+
+		emails = append(emails, invite.UserEmail)
+		members = append(members, map[string]interface{}{
+			"user_email":            invite.UserEmail,
+			"user_id":               "",
+			"real_name":             "",
+			"invited_by_user_email": invite.InvitedByUserEmail,
+			"accepted":              false,
+			"create_time":           optionalTimeString(invite.CreateTime),
+		})
+	}
+
+	if err := d.Set("account_id", accountId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("team_id", teamId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("user_emails", emails); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("members", members); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAccountTeamMembersDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	accountId, teamId := splitResourceID2(d.Id())
+
+	if err := syncAccountTeamMembers(m.(*providerMeta).client, accountId, teamId, nil); err != nil && !aiven.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
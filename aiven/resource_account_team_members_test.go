@@ -0,0 +1,79 @@
+package aiven
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+func Test_diffAccountTeamMembers(t *testing.T) {
+	members := []aiven.AccountTeamMember{
+		{UserId: "u1", UserEmail: "kept@example.com"},
+		{UserId: "u2", UserEmail: "removed@example.com"},
+	}
+	invites := []aiven.AccountTeamInvite{
+		{UserEmail: "pending@example.com"},
+		{UserEmail: "revoked@example.com"},
+	}
+
+	tests := []struct {
+		name    string
+		emails  []string
+		members []aiven.AccountTeamMember
+		invites []aiven.AccountTeamInvite
+		want    accountTeamMembersChanges
+	}{
+		{
+			"in sync",
+			[]string{"kept@example.com", "removed@example.com", "pending@example.com", "revoked@example.com"},
+			members,
+			invites,
+			accountTeamMembersChanges{},
+		},
+		{
+			"members and invitations are removed separately",
+			[]string{"kept@example.com", "pending@example.com", "new@example.com"},
+			members,
+			invites,
+			accountTeamMembersChanges{
+				invite:        []string{"new@example.com"},
+				deleteMembers: []aiven.AccountTeamMember{{UserId: "u2", UserEmail: "removed@example.com"}},
+				deleteInvites: []string{"revoked@example.com"},
+			},
+		},
+		{
+			"expired invitation is sent again",
+			[]string{"expired@example.com"},
+			nil,
+			nil,
+			accountTeamMembersChanges{invite: []string{"expired@example.com"}},
+		},
+		{
+			"invited member is removed as a member",
+			nil,
+			[]aiven.AccountTeamMember{{UserId: "u1", UserEmail: "kept@example.com"}},
+			[]aiven.AccountTeamInvite{{UserEmail: "kept@example.com"}},
+			accountTeamMembersChanges{
+				deleteMembers: []aiven.AccountTeamMember{{UserId: "u1", UserEmail: "kept@example.com"}},
+			},
+		},
+		{
+			"no emails",
+			nil,
+			members,
+			invites,
+			accountTeamMembersChanges{
+				deleteMembers: members,
+				deleteInvites: []string{"pending@example.com", "revoked@example.com"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := diffAccountTeamMembers(tt.emails, tt.members, tt.invites); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffAccountTeamMembers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
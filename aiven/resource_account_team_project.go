@@ -30,7 +30,7 @@ var aivenAccountTeamProjectSchema = map[string]*schema.Schema{
 		Type:         schema.TypeString,
 		Description:  "Account team project type, can one of the following values: admin, developer, operator and read_only",
 		Optional:     true,
-		ValidateFunc: validation.StringInSlice([]string{"admin", "developer", "operator", "read_only"}, false),
+		ValidateFunc: validation.StringInSlice(accountTeamProjectTypes, false),
 	},
 }
 
@@ -49,7 +49,7 @@ func resourceAccountTeamProject() *schema.Resource {
 }
 
 func resourceAccountTeamProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId := d.Get("account_id").(string)
 	teamId := d.Get("team_id").(string)
@@ -74,7 +74,7 @@ func resourceAccountTeamProjectCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceAccountTeamProjectRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, teamId, projectName := splitResourceID3(d.Id())
 	r, err := client.AccountTeamProjects.List(accountId, teamId)
@@ -110,7 +110,7 @@ func resourceAccountTeamProjectRead(_ context.Context, d *schema.ResourceData, m
 }
 
 func resourceAccountTeamProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	accountId, teamId, _ := splitResourceID3(d.Id())
 	newProjectName := d.Get("project_name").(string)
@@ -130,7 +130,7 @@ func resourceAccountTeamProjectUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceAccountTeamProjectDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	err := client.AccountTeamProjects.Delete(splitResourceID3(d.Id()))
 	if err != nil && !aiven.IsNotFound(err) {
@@ -0,0 +1,198 @@
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var accountTeamProjectTypes = []string{"admin", "developer", "operator", "read_only"}
+
+var aivenAccountTeamProjectsSchema = map[string]*schema.Schema{
+	"account_id": {
+		Type:        schema.TypeString,
+		Description: "Account id",
+		Required:    true,
+		ForceNew:    true,
+	},
+	"team_id": {
+		Type:        schema.TypeString,
+		Description: "Account team id",
+		Required:    true,
+		ForceNew:    true,
+	},
+	"project_permissions": {
+		Type:         schema.TypeMap,
+		Description:  "Map of project name to account team project type, projects missing from the map are revoked from the team",
+		Required:     true,
+		Elem:         &schema.Schema{Type: schema.TypeString},
+		ValidateFunc: validateAccountTeamProjectPermissions,
+	},
+}
+
+func resourceAccountTeamProjects() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAccountTeamProjectsCreate,
+		ReadContext:   resourceAccountTeamProjectsRead,
+		UpdateContext: resourceAccountTeamProjectsUpdate,
+		DeleteContext: resourceAccountTeamProjectsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenAccountTeamProjectsSchema,
+	}
+}
+
+func validateAccountTeamProjectPermissions(v interface{}, k string) (ws []string, errors []error) {
+	for project, teamType := range v.(map[string]interface{}) {
+		valid := false
+		for _, t := range accountTeamProjectTypes {
+			if teamType == t {
+				valid = true
+			}
+		}
+
+		if !valid {
+			errors = append(errors, fmt.Errorf("%q: invalid team type `%v` for project %s, expected one of %v",
+				k, teamType, project, accountTeamProjectTypes))
+		}
+	}
+
+	return
+}
+
+// accountTeamProjectsChanges lists the grants needed to turn the current team projects
+// into the desired permission matrix
+type accountTeamProjectsChanges struct {
+	create []aiven.AccountTeamProject
+	update []aiven.AccountTeamProject
+	delete []string
+}
+
+func diffAccountTeamProjects(permissions map[string]string, current []aiven.AccountTeamProject) accountTeamProjectsChanges {
+	granted := make(map[string]string)
+	for _, p := range current {
+		granted[p.ProjectName] = p.TeamType
+	}
+
+	diff := diffStringMaps(granted, permissions)
+
+	changes := accountTeamProjectsChanges{delete: diff.delete}
+	for _, projectName := range diff.create {
+		changes.create = append(changes.create, aiven.AccountTeamProject{ProjectName: projectName, TeamType: permissions[projectName]})
+	}
+	for _, projectName := range diff.update {
+		changes.update = append(changes.update, aiven.AccountTeamProject{ProjectName: projectName, TeamType: permissions[projectName]})
+	}
+
+	return changes
+}
+
+func resourceAccountTeamProjectsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	accountId := d.Get("account_id").(string)
+	teamId := d.Get("team_id").(string)
+
+	if err := syncAccountTeamProjects(m.(*providerMeta).client, accountId, teamId, accountTeamProjectPermissions(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(accountId, teamId))
+
+	return resourceAccountTeamProjectsRead(ctx, d, m)
+}
+
+func resourceAccountTeamProjectsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	accountId, teamId := splitResourceID2(d.Id())
+
+	if err := syncAccountTeamProjects(m.(*providerMeta).client, accountId, teamId, accountTeamProjectPermissions(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAccountTeamProjectsRead(ctx, d, m)
+}
+
+func accountTeamProjectPermissions(d *schema.ResourceData) map[string]string {
+	permissions := make(map[string]string)
+	for projectName, teamType := range d.Get("project_permissions").(map[string]interface{}) {
+		permissions[projectName] = teamType.(string)
+	}
+
+	return permissions
+}
+
+// syncAccountTeamProjects grants, updates and revokes team project permissions so that
+// they match the permissions map
+func syncAccountTeamProjects(client *aiven.Client, accountId, teamId string, permissions map[string]string) error {
+	r, err := client.AccountTeamProjects.List(accountId, teamId)
+	if err != nil {
+		return err
+	}
+
+	changes := diffAccountTeamProjects(permissions, r.Projects)
+
+	for _, projectName := range changes.delete {
+		log.Printf("[DEBUG] revoking account team access to project %s", projectName)
+		err := client.AccountTeamProjects.Delete(accountId, teamId, projectName)
+		if err != nil && !aiven.IsNotFound(err) {
+			return fmt.Errorf("cannot revoke account team access to project %s: %w", projectName, err)
+		}
+	}
+
+	for _, p := range changes.update {
+		log.Printf("[DEBUG] changing account team access to project %s to %s", p.ProjectName, p.TeamType)
+		if err := client.AccountTeamProjects.Update(accountId, teamId, p); err != nil {
+			return fmt.Errorf("cannot update account team access to project %s: %w", p.ProjectName, err)
+		}
+	}
+
+	for _, p := range changes.create {
+		log.Printf("[DEBUG] granting account team %s access to project %s", p.TeamType, p.ProjectName)
+		if err := client.AccountTeamProjects.Create(accountId, teamId, p); err != nil {
+			return fmt.Errorf("cannot grant account team access to project %s: %w", p.ProjectName, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceAccountTeamProjectsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	accountId, teamId := splitResourceID2(d.Id())
+
+	r, err := client.AccountTeamProjects.List(accountId, teamId)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	permissions := make(map[string]string)
+	for _, p := range r.Projects {
+		permissions[p.ProjectName] = p.TeamType
+	}
+
+	if err := d.Set("account_id", accountId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("team_id", teamId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("project_permissions", permissions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAccountTeamProjectsDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	accountId, teamId := splitResourceID2(d.Id())
+
+	if err := syncAccountTeamProjects(m.(*providerMeta).client, accountId, teamId, nil); err != nil && !aiven.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package aiven
+
+import (
+	"testing"
+)
+
+func Test_validateAccountTeamProjectPermissions(t *testing.T) {
+	if _, errs := validateAccountTeamProjectPermissions(map[string]interface{}{"a": "admin", "b": "read_only"}, "project_permissions"); len(errs) != 0 {
+		t.Errorf("validateAccountTeamProjectPermissions() unexpected errors %v", errs)
+	}
+	if _, errs := validateAccountTeamProjectPermissions(map[string]interface{}{"a": "owner"}, "project_permissions"); len(errs) != 1 {
+		t.Errorf("validateAccountTeamProjectPermissions() expected an error for invalid team type")
+	}
+}
@@ -4,29 +4,14 @@ package aiven
 
 import (
 	"context"
-	"fmt"
-	"log"
 	"time"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-var aivenAWSPrivatelinkSchema = map[string]*schema.Schema{
-	"project": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Project name",
-		ForceNew:    true,
-	},
-	"service_name": {
-		Type:        schema.TypeString,
-		Required:    true,
-		Description: "Service name",
-		ForceNew:    true,
-	},
+var aivenAWSPrivatelinkSchema = privatelinkSchema(map[string]*schema.Schema{
 	"principals": {
 		Type:        schema.TypeSet,
 		Required:    true,
@@ -43,7 +28,7 @@ var aivenAWSPrivatelinkSchema = map[string]*schema.Schema{
 		Computed:    true,
 		Description: "AWS service name",
 	},
-}
+})
 
 func resourceAWSPrivatelink() *schema.Resource {
 	return &schema.Resource{
@@ -52,7 +37,7 @@ func resourceAWSPrivatelink() *schema.Resource {
 		UpdateContext: resourceAWSPrivatelinkUpdate,
 		DeleteContext: resourceAWSPrivatelinkDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: resourceAWSPrivatelinkState,
+			StateContext: privatelinkImportState(resourceAWSPrivatelinkRead, "AWS"),
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
@@ -64,7 +49,7 @@ func resourceAWSPrivatelink() *schema.Resource {
 }
 
 func resourceAWSPrivatelinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	var principals []string
 	var project = d.Get("project").(string)
@@ -84,11 +69,7 @@ func resourceAWSPrivatelinkCreate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	// Wait until the AWS privatelink is active
-	w := &AWSPrivatelinkWaiter{
-		Client:      m.(*aiven.Client),
-		Project:     project,
-		ServiceName: serviceName,
-	}
+	w := awsPrivatelinkWaiter(client, project, serviceName)
 
 	_, err = w.Conf(d.Timeout(schema.TimeoutCreate)).WaitForStateContext(ctx)
 	if err != nil {
@@ -101,7 +82,7 @@ func resourceAWSPrivatelinkCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceAWSPrivatelinkRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName := splitResourceID2(d.Id())
 	p, err := client.AWSPrivatelink.Get(project, serviceName)
@@ -128,7 +109,7 @@ func resourceAWSPrivatelinkRead(_ context.Context, d *schema.ResourceData, m int
 	return nil
 }
 func resourceAWSPrivatelinkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName := splitResourceID2(d.Id())
 
@@ -147,11 +128,7 @@ func resourceAWSPrivatelinkUpdate(ctx context.Context, d *schema.ResourceData, m
 	}
 
 	// Wait until the AWS privatelink is active
-	w := &AWSPrivatelinkWaiter{
-		Client:      m.(*aiven.Client),
-		Project:     project,
-		ServiceName: serviceName,
-	}
+	w := awsPrivatelinkWaiter(client, project, serviceName)
 
 	_, err = w.Conf(d.Timeout(schema.TimeoutCreate)).WaitForStateContext(ctx)
 	if err != nil {
@@ -162,7 +139,7 @@ func resourceAWSPrivatelinkUpdate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceAWSPrivatelinkDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	err := client.AWSPrivatelink.Delete(splitResourceID2(d.Id()))
 	if err != nil && !aiven.IsNotFound(err) {
@@ -172,45 +149,16 @@ func resourceAWSPrivatelinkDelete(_ context.Context, d *schema.ResourceData, m i
 	return nil
 }
 
-func resourceAWSPrivatelinkState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	di := resourceAWSPrivatelinkRead(ctx, d, m)
-	if di.HasError() {
-		return nil, fmt.Errorf("cannot get AWS privatelink %v", di)
-	}
-
-	return []*schema.ResourceData{d}, nil
-}
-
-// AWSPrivatelinkWaiter is used to wait for Aiven to build a AWS privatelink
-type AWSPrivatelinkWaiter struct {
-	Client      *aiven.Client
-	Project     string
-	ServiceName string
-}
-
-// RefreshFunc will call the Aiven client and refresh it's state.
-func (w *AWSPrivatelinkWaiter) RefreshFunc() resource.StateRefreshFunc {
-	return func() (interface{}, string, error) {
-		pc, err := w.Client.AWSPrivatelink.Get(w.Project, w.ServiceName)
-		if err != nil {
-			return nil, "", err
-		}
-
-		log.Printf("[DEBUG] Got %s state while waiting for AWS privatelink to be active.", pc.State)
-
-		return pc, pc.State, nil
-	}
-}
-
-// Conf sets up the configuration to refresh.
-func (w *AWSPrivatelinkWaiter) Conf(timeout time.Duration) *resource.StateChangeConf {
-	log.Printf("[DEBUG] Create waiter timeout %.0f minutes", timeout.Minutes())
-
-	return &resource.StateChangeConf{
-		Pending: []string{"creating"},
-		Target:  []string{"active"},
-		Refresh: w.RefreshFunc(),
-		Delay:   10 * time.Second,
-		Timeout: timeout,
+// awsPrivatelinkWaiter is used to wait for Aiven to build a AWS privatelink
+func awsPrivatelinkWaiter(client *aiven.Client, project, serviceName string) *PrivatelinkWaiter {
+	return &PrivatelinkWaiter{
+		Cloud: "AWS",
+		Get: func() (interface{}, string, error) {
+			p, err := client.AWSPrivatelink.Get(project, serviceName)
+			if err != nil {
+				return nil, "", err
+			}
+			return p, p.State, nil
+		},
 	}
 }
@@ -0,0 +1,183 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// azurePrivatelink is the Azure Private Link service of an Aiven service
+type azurePrivatelink struct {
+	aiven.APIResponse
+	AzureServiceAlias   string   `json:"azure_service_alias"`
+	AzureServiceID      string   `json:"azure_service_id"`
+	Message             string   `json:"message"`
+	State               string   `json:"state"`
+	UserSubscriptionIDs []string `json:"user_subscription_ids"`
+}
+
+type azurePrivatelinkRequest struct {
+	UserSubscriptionIDs []string `json:"user_subscription_ids"`
+}
+
+var azurePrivatelinkCloud = privatelinkCloud{
+	name:                "azure",
+	title:               "Azure",
+	endpointKey:         "private_endpoint_id",
+	endpointDescription: "Azure private endpoint resource ID",
+}
+
+var aivenAzurePrivatelinkSchema = privatelinkSchema(map[string]*schema.Schema{
+	"user_subscription_ids": {
+		Type:        schema.TypeSet,
+		Required:    true,
+		Description: "Azure subscriptions allowed to connect to the Private Link service",
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
+	"azure_service_alias": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Azure Private Link service alias",
+	},
+	"azure_service_id": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Azure Private Link service ID",
+	},
+	"message": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Printable result of the Azure Private Link request",
+	},
+	"state": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Privatelink resource state",
+	},
+})
+
+func resourceAzurePrivatelink() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAzurePrivatelinkCreate,
+		ReadContext:   resourceAzurePrivatelinkRead,
+		UpdateContext: resourceAzurePrivatelinkUpdate,
+		DeleteContext: resourceAzurePrivatelinkDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: privatelinkImportState(resourceAzurePrivatelinkRead, "Azure"),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: aivenAzurePrivatelinkSchema,
+	}
+}
+
+func getAzurePrivatelink(ctx context.Context, client *aiven.Client, project, serviceName string) (*azurePrivatelink, error) {
+	var p azurePrivatelink
+	if err := aivenAPIRequest(ctx, client, http.MethodGet, privatelinkPath(project, serviceName, "azure"), nil, &p); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// putAzurePrivatelink creates or updates the privatelink and waits until it is active
+func putAzurePrivatelink(ctx context.Context, d *schema.ResourceData, client *aiven.Client, method string, timeout time.Duration) error {
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	err := aivenAPIRequest(ctx, client, method, privatelinkPath(project, serviceName, "azure"),
+		azurePrivatelinkRequest{UserSubscriptionIDs: flattenToString(d.Get("user_subscription_ids").(*schema.Set).List())}, nil)
+	if err != nil {
+		return err
+	}
+
+	w := &PrivatelinkWaiter{
+		Cloud: "Azure",
+		Get: func() (interface{}, string, error) {
+			p, err := getAzurePrivatelink(ctx, client, project, serviceName)
+			if err != nil {
+				return nil, "", err
+			}
+			return p, p.State, nil
+		},
+	}
+
+	_, err = w.Conf(timeout).WaitForStateContext(ctx)
+	return err
+}
+
+func resourceAzurePrivatelinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := putAzurePrivatelink(ctx, d, m.(*providerMeta).client, http.MethodPost, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.Errorf("Error waiting for Azure privatelink creation: %s", err)
+	}
+
+	d.SetId(buildResourceID(d.Get("project").(string), d.Get("service_name").(string)))
+
+	return resourceAzurePrivatelinkRead(ctx, d, m)
+}
+
+func resourceAzurePrivatelinkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName := splitResourceID2(d.Id())
+	p, err := getAzurePrivatelink(ctx, m.(*providerMeta).client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("user_subscription_ids", p.UserSubscriptionIDs); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("azure_service_alias", p.AzureServiceAlias); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("azure_service_id", p.AzureServiceID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("message", p.Message); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("state", p.State); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceAzurePrivatelinkUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := putAzurePrivatelink(ctx, d, m.(*providerMeta).client, http.MethodPut, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.Errorf("Error waiting for Azure privatelink to be updated: %s", err)
+	}
+
+	return resourceAzurePrivatelinkRead(ctx, d, m)
+}
+
+func resourceAzurePrivatelinkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName := splitResourceID2(d.Id())
+
+	err := aivenAPIRequest(ctx, m.(*providerMeta).client, http.MethodDelete, privatelinkPath(project, serviceName, "azure"), nil, nil)
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func datasourceAzurePrivatelink() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: privatelinkDatasourceRead(resourceAzurePrivatelinkRead),
+		Schema:      resourceSchemaAsDatasourceSchema(aivenAzurePrivatelinkSchema, "project", "service_name"),
+	}
+}
@@ -106,7 +106,7 @@ func resourceBillingGroup() *schema.Resource {
 }
 
 func resourceBillingGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	var billingEmails []*aiven.ContactEmail
 	if emails := contactEmailListForAPI(d, "billing_emails", true); emails != nil {
@@ -145,7 +145,7 @@ func resourceBillingGroupCreate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceBillingGroupRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	bg, err := client.BillingGroup.Get(d.Id())
 	if err != nil {
@@ -196,7 +196,7 @@ func resourceBillingGroupRead(_ context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceBillingGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	var billingEmails []*aiven.ContactEmail
 	if emails := contactEmailListForAPI(d, "billing_emails", true); emails != nil {
@@ -236,7 +236,7 @@ func resourceBillingGroupUpdate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceBillingGroupDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	err := client.BillingGroup.Delete(d.Id())
 	if err != nil && !aiven.IsNotFound(err) {
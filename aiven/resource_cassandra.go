@@ -9,6 +9,8 @@ import (
 
 func cassandraSchema() map[string]*schema.Schema {
 	s := serviceCommonSchema()
+	s["allow_major_upgrade"] = serviceAllowMajorUpgradeSchema()
+	s["restore_from"] = serviceRestoreFromSchema()
 	s[ServiceTypeCassandra] = &schema.Schema{
 		Type:        schema.TypeList,
 		MaxItems:    1,
@@ -43,6 +45,7 @@ func resourceCassandra() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeCassandra),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
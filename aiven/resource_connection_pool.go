@@ -4,7 +4,9 @@ package aiven
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
+	"time"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -72,18 +74,188 @@ func resourceConnectionPool() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceConnectionPoolState,
 		},
+		CustomizeDiff: resourceConnectionPoolCustomizeDiff,
 
 		Schema: aivenConnectionPoolSchema,
 	}
 }
 
+// connectionPoolSQLTimeout limits how long reading the connection limit of a service may take
+const connectionPoolSQLTimeout = 10 * time.Second
+
+// resourceConnectionPoolCustomizeDiff checks that the pools of an existing service do not
+// use more backend connections than the service allows; the sizes of pools created or
+// resized in the same plan are only counted together when they are applied
+func resourceConnectionPoolCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("pool_size") || !d.NewValueKnown("project") || !d.NewValueKnown("service_name") {
+		return nil
+	}
+
+	client := m.(*providerMeta).client
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	// the service may be created in the same plan or be unreachable, the sizes are checked
+	// again when the pool is applied
+	service, err := client.Services.Get(project, serviceName)
+	if err != nil {
+		log.Printf("[WARN] cannot check pool_size of %s/%s at plan time: %s", project, serviceName, err)
+		return nil
+	}
+
+	// a plan cannot show warnings, they are shown when the plan is applied
+	diags := checkConnectionPoolSizes(ctx, client, project, service, d.Get("pool_name").(string), d.Get("pool_size").(int))
+	for _, di := range diags {
+		if di.Severity == diag.Error {
+			return fmt.Errorf("%s", di.Summary)
+		}
+		log.Printf("[WARN] %s: %s", di.Summary, di.Detail)
+	}
+
+	return nil
+}
+
+// checkConnectionPoolSizes checks the pool sizes of a service, with the given pool resized,
+// against the backend connections of the service; a warning is returned when the limit
+// cannot be read
+func checkConnectionPoolSizes(
+	ctx context.Context,
+	client *aiven.Client,
+	project string,
+	service *aiven.Service,
+	poolName string,
+	poolSize int,
+) diag.Diagnostics {
+	maxConnections, _, err := connectionPoolServiceLimits(ctx, client, project, service, "", "")
+	if maxConnections == 0 {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("cannot check pool_size against the connection limit of service %s/%s", project, service.Name),
+			Detail:   fmt.Sprintf("The limit could not be read through the SQL endpoint of the service: %s", err),
+		}}
+	}
+
+	if err := validateConnectionPoolSizes(service.ConnectionPools, poolName, poolSize, maxConnections); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// validateConnectionPoolSizes checks that the pool sizes of a service, with the given pool
+// resized, fit into the backend connections of the service
+func validateConnectionPoolSizes(pools []*aiven.ConnectionPool, poolName string, poolSize, maxConnections int) error {
+	total := poolSize
+	for _, p := range pools {
+		if p.PoolName != poolName {
+			total += p.PoolSize
+		}
+	}
+
+	if total > maxConnections {
+		return fmt.Errorf("connection pools of the service would use %d connections, the service allows %d; "+
+			"reduce pool_size or upgrade the service plan", total, maxConnections)
+	}
+
+	return nil
+}
+
+// connectionPoolServiceLimits reads the backend connections pools of a PostgreSQL service
+// can use and, when database and username are given, how many connections that user has
+// open to the database; when the SQL endpoint cannot be reached the limit falls back to
+// max_connections of the user config, which is zero when not set, and the error is returned
+func connectionPoolServiceLimits(
+	ctx context.Context,
+	client *aiven.Client,
+	project string,
+	service *aiven.Service,
+	database, username string,
+) (maxConnections, serverConnections int, err error) {
+	if pg, ok := service.UserConfig["pg"].(map[string]interface{}); ok {
+		if v, ok := pg["max_connections"].(float64); ok {
+			maxConnections = int(v)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, connectionPoolSQLTimeout)
+	defer cancel()
+
+	db, err := pgConnect(ctx, client, project, service.Name, "defaultdb")
+	if err != nil {
+		return maxConnections, 0, err
+	}
+	defer db.Close()
+
+	var limit int
+	err = db.QueryRowContext(ctx, `SELECT current_setting('max_connections')::int -
+		current_setting('superuser_reserved_connections')::int`).Scan(&limit)
+	if err != nil {
+		return maxConnections, 0, fmt.Errorf("cannot read max_connections: %w", err)
+	}
+	maxConnections = limit
+
+	if database != "" && username != "" {
+		err = db.QueryRowContext(ctx, `SELECT count(*) FROM pg_stat_activity WHERE datname = $1 AND usename = $2`,
+			database, username).Scan(&serverConnections)
+		if err != nil {
+			log.Printf("[DEBUG] cannot read connections of service %s/%s: %s", project, service.Name, err)
+		}
+	}
+
+	return maxConnections, serverConnections, nil
+}
+
+// validateConnectionPoolTarget checks that the database and user of a pool exist, they are
+// created before the pool when referenced from their resources; it runs when the pool is
+// applied
+func validateConnectionPoolTarget(client *aiven.Client, project string, service *aiven.Service, database, username string) error {
+	found := false
+	for _, u := range service.Users {
+		if u.Username == username {
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("service user %s does not exist in service %s/%s", username, project, service.Name)
+	}
+
+	databases, err := client.Databases.List(project, service.Name)
+	if err != nil {
+		return err
+	}
+
+	for _, db := range databases {
+		if db.DatabaseName == database {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("database %s does not exist in service %s/%s", database, project, service.Name)
+}
+
 func resourceConnectionPoolCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
 	poolName := d.Get("pool_name").(string)
-	_, err := client.ConnectionPools.Create(
+	service, err := client.Services.Get(project, serviceName)
+	if err != nil {
+		return diag.Errorf("cannot get a service: %s", err)
+	}
+
+	err = validateConnectionPoolTarget(client, project, service, d.Get("database_name").(string), d.Get("username").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// pools created in the same apply are counted here, the plan only knows existing pools
+	diags := checkConnectionPoolSizes(ctx, client, project, service, poolName, d.Get("pool_size").(int))
+	if diags.HasError() {
+		return diags
+	}
+
+	_, err = client.ConnectionPools.Create(
 		project,
 		serviceName,
 		aiven.CreateConnectionPoolRequest{
@@ -100,11 +272,11 @@ func resourceConnectionPoolCreate(ctx context.Context, d *schema.ResourceData, m
 
 	d.SetId(buildResourceID(project, serviceName, poolName))
 
-	return resourceConnectionPoolRead(ctx, d, m)
+	return append(diags, resourceConnectionPoolRead(ctx, d, m)...)
 }
 
 func resourceConnectionPoolRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, poolName := splitResourceID3(d.Id())
 	pool, err := client.ConnectionPools.Get(project, serviceName, poolName)
@@ -121,9 +293,30 @@ func resourceConnectionPoolRead(_ context.Context, d *schema.ResourceData, m int
 }
 
 func resourceConnectionPoolUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, poolName := splitResourceID3(d.Id())
+	var diags diag.Diagnostics
+	if d.HasChanges("username", "pool_size") {
+		service, err := client.Services.Get(project, serviceName)
+		if err != nil {
+			return diag.Errorf("cannot get a service: %s", err)
+		}
+
+		if d.HasChange("username") {
+			err := validateConnectionPoolTarget(client, project, service, d.Get("database_name").(string), d.Get("username").(string))
+			if err != nil {
+				return diag.FromErr(err)
+			}
+		}
+
+		if d.HasChange("pool_size") {
+			if diags = checkConnectionPoolSizes(ctx, client, project, service, poolName, d.Get("pool_size").(int)); diags.HasError() {
+				return diags
+			}
+		}
+	}
+
 	_, err := client.ConnectionPools.Update(
 		project,
 		serviceName,
@@ -139,11 +332,11 @@ func resourceConnectionPoolUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.FromErr(err)
 	}
 
-	return resourceConnectionPoolRead(ctx, d, m)
+	return append(diags, resourceConnectionPoolRead(ctx, d, m)...)
 }
 
 func resourceConnectionPoolDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, poolName := splitResourceID3(d.Id())
 	err := client.ConnectionPools.Delete(projectName, serviceName, poolName)
@@ -175,7 +175,7 @@ func testAccCheckAivenConnectionPoolAttributes(n string) resource.TestCheckFunc
 }
 
 func testAccCheckAivenConnectionPoolResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each connection pool is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -198,3 +198,21 @@ func testAccCheckAivenConnectionPoolResourceDestroy(s *terraform.State) error {
 
 	return nil
 }
+
+func Test_validateConnectionPoolSizes(t *testing.T) {
+	pools := []*aiven.ConnectionPool{
+		{PoolName: "a", PoolSize: 40},
+		{PoolName: "b", PoolSize: 30},
+	}
+
+	if err := validateConnectionPoolSizes(pools, "c", 30, 100); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := validateConnectionPoolSizes(pools, "c", 31, 100); err == nil {
+		t.Error("expected an error when the pools exceed the connection limit")
+	}
+	// a resized pool replaces its old size
+	if err := validateConnectionPoolSizes(pools, "a", 70, 100); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
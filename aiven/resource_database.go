@@ -5,6 +5,7 @@ package aiven
 import (
 	"context"
 	"fmt"
+	"log"
 	"strings"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
 )
 
 const defaultLC = "en_US.UTF-8"
@@ -67,6 +69,17 @@ var aivenDatabaseSchema = map[string]*schema.Schema{
 			from being deleted by Terraform. It is recommended to enable this for any production
 			databases containing critical data.`,
 	},
+	"prevent_destroy_reason": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Reason shown when termination_protection prevents deleting or replacing the database",
+	},
+	"require_empty": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Only delete the database when it has no tables and no active client connections, supported for PostgreSQL and MySQL",
+	},
 }
 
 func resourceDatabase() *schema.Resource {
@@ -82,13 +95,15 @@ func resourceDatabase() *schema.Resource {
 			Delete: schema.DefaultTimeout(2 * time.Minute),
 		},
 
+		CustomizeDiff: resourceTerminationProtectionCustomizeDiff("database", "database_name", aivenDatabaseSchema),
+
 		// TODO: add user config
 		Schema: aivenDatabaseSchema,
 	}
 }
 
 func resourceDatabaseCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -116,7 +131,7 @@ func resourceDatabaseUpdate(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceDatabaseRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, databaseName := splitResourceID3(d.Id())
 	database, err := client.Databases.Get(projectName, serviceName, databaseName)
@@ -147,12 +162,18 @@ func resourceDatabaseRead(_ context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceDatabaseDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, databaseName := splitResourceID3(d.Id())
 
 	if d.Get("termination_protection").(bool) {
-		return diag.Errorf("cannot delete a database termination_protection is enabled")
+		return diag.FromErr(terminationProtectionError("database", databaseName, d.Get("prevent_destroy_reason").(string)))
+	}
+
+	if d.Get("require_empty").(bool) {
+		if err := checkDatabaseEmpty(ctx, client, projectName, serviceName, databaseName); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	waiter := DatabaseDeleteWaiter{
@@ -184,6 +205,79 @@ func resourceDatabaseState(ctx context.Context, d *schema.ResourceData, m interf
 	return []*schema.ResourceData{d}, nil
 }
 
+// checkDatabaseEmpty connects to the database through the SQL endpoint of the service and
+// fails when it has tables or active connections; only connections of the service users
+// listed by the API are counted, the monitoring, backup and replication sessions of Aiven
+// use service internal users
+func checkDatabaseEmpty(ctx context.Context, client *aiven.Client, projectName, serviceName, databaseName string) error {
+	service, err := client.Services.Get(projectName, serviceName)
+	if err != nil {
+		return err
+	}
+
+	var usernames []string
+	for _, u := range service.Users {
+		usernames = append(usernames, u.Username)
+	}
+
+	var tables, connections int
+	switch service.Type {
+	case ServiceTypePG:
+		db, err := pgConnect(ctx, client, projectName, serviceName, databaseName)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		err = db.QueryRowContext(ctx, `SELECT count(*) FROM pg_tables
+			WHERE schemaname NOT IN ('pg_catalog', 'information_schema')`).Scan(&tables)
+		if err != nil {
+			return err
+		}
+
+		err = db.QueryRowContext(ctx, `SELECT count(*) FROM pg_stat_activity
+			WHERE datname = $1 AND pid <> pg_backend_pid() AND backend_type = 'client backend'
+			AND usename = ANY($2)`, databaseName, pq.Array(usernames)).Scan(&connections)
+		if err != nil {
+			return err
+		}
+	case ServiceTypeMySQL:
+		db, err := mysqlConnect(ctx, client, projectName, serviceName)
+		if err != nil {
+			return err
+		}
+		defer db.Close()
+
+		err = db.QueryRowContext(ctx, `SELECT count(*) FROM information_schema.TABLES
+			WHERE TABLE_SCHEMA = ?`, databaseName).Scan(&tables)
+		if err != nil {
+			return err
+		}
+
+		if len(usernames) > 0 {
+			args := []interface{}{databaseName}
+			for _, u := range usernames {
+				args = append(args, u)
+			}
+			err = db.QueryRowContext(ctx, `SELECT count(*) FROM information_schema.PROCESSLIST
+				WHERE DB = ? AND ID <> CONNECTION_ID() AND COMMAND NOT IN ('Binlog Dump', 'Binlog Dump GTID', 'Daemon')
+				AND USER IN (?`+strings.Repeat(", ?", len(usernames)-1)+`)`, args...).Scan(&connections)
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("require_empty is not supported for %s services", service.Type)
+	}
+
+	if tables > 0 || connections > 0 {
+		return fmt.Errorf("cannot delete database %s, require_empty is enabled and it has %d tables and %d active connections",
+			databaseName, tables, connections)
+	}
+
+	return nil
+}
+
 // DatabaseDeleteWaiter is used to wait for Database to be deleted.
 type DatabaseDeleteWaiter struct {
 	Client      *aiven.Client
@@ -197,7 +291,11 @@ func (w *DatabaseDeleteWaiter) RefreshFunc() resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		err := w.Client.Databases.Delete(w.ProjectName, w.ServiceName, w.Database)
 		if err != nil && !aiven.IsNotFound(err) {
-			return nil, "REMOVING", nil
+			if isRetryableDeleteError(err) {
+				log.Printf("[DEBUG] retrying delete of database %s: %s", w.Database, err)
+				return nil, "REMOVING", nil
+			}
+			return nil, "", err
 		}
 
 		return aiven.Database{}, "DELETED", nil
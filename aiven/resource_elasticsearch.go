@@ -10,6 +10,8 @@ import (
 
 func elasticsearchSchema() map[string]*schema.Schema {
 	s := serviceCommonSchema()
+	s["allow_major_upgrade"] = serviceAllowMajorUpgradeSchema()
+	s["restore_from"] = serviceRestoreFromSchema()
 	s[ServiceTypeElasticsearch] = &schema.Schema{
 		Type:        schema.TypeList,
 		MaxItems:    1,
@@ -56,6 +58,7 @@ func resourceElasticsearch() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeElasticsearch),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
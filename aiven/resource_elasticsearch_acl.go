@@ -89,7 +89,7 @@ func resourceElasticsearchACL() *schema.Resource {
 }
 
 func resourceElasticsearchACLRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName := splitResourceID2(d.Id())
 	r, err := client.ElasticsearchACLs.Get(project, serviceName)
@@ -152,13 +152,14 @@ func resourceElasticsearchACLState(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceElasticsearchACLUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
 
-	resourceElasticsearchACLModifierMutex.Lock()
-	defer resourceElasticsearchACLModifierMutex.Unlock()
+	mu := resourceElasticsearchACLServiceMutex(project, serviceName)
+	mu.Lock()
+	defer mu.Unlock()
 
 	var config aiven.ElasticSearchACLConfig
 	for _, aclD := range d.Get("acl").(*schema.Set).List() {
@@ -191,13 +192,14 @@ func resourceElasticsearchACLUpdate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceElasticsearchACLDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
 
-	resourceElasticsearchACLModifierMutex.Lock()
-	defer resourceElasticsearchACLModifierMutex.Unlock()
+	mu := resourceElasticsearchACLServiceMutex(project, serviceName)
+	mu.Lock()
+	defer mu.Unlock()
 
 	_, err := client.ElasticsearchACLs.Update(
 		project,
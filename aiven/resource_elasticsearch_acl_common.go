@@ -1,41 +1,145 @@
 package aiven
 
 import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/aiven/aiven-go-client"
 )
 
+// resourceElasticsearchACLModifyRetries is how many times a remote config change is
+// retried when another client modified the config concurrently
+const resourceElasticsearchACLModifyRetries = 3
+
 var (
-	// this mutex is needed to serialize calls to modify the remote config
+	// these mutexes are needed to serialize calls to modify the remote config of a service
 	// since its an abstraction that first GETs, modifies and then PUTs again
-	resourceElasticsearchACLModifierMutex sync.Mutex
+	resourceElasticsearchACLModifierMutexes   = make(map[string]*sync.Mutex)
+	resourceElasticsearchACLModifierMutexesMu sync.Mutex
 )
 
+// resourceElasticsearchACLServiceMutex returns the mutex that serializes ACL config
+// modifications of a single service
+func resourceElasticsearchACLServiceMutex(project, serviceName string) *sync.Mutex {
+	resourceElasticsearchACLModifierMutexesMu.Lock()
+	defer resourceElasticsearchACLModifierMutexesMu.Unlock()
+
+	key := buildResourceID(project, serviceName)
+	if _, ok := resourceElasticsearchACLModifierMutexes[key]; !ok {
+		resourceElasticsearchACLModifierMutexes[key] = &sync.Mutex{}
+	}
+
+	return resourceElasticsearchACLModifierMutexes[key]
+}
+
 // GETs the remote config, applies the modifiers and PUTs it again
 // The Config that is passed to the modifiers is guaranteed to be not nil
+// The remote config is read again right before the PUT, if somebody else changed it since the
+// first GET the modifiers are applied again to the fresh config so that the other change is
+// kept. When that keeps happening an error describing the concurrent change is returned. The
+// API has no conditional update, a change made between the last GET and the PUT is still lost
 func resourceElasticsearchACLModifyRemoteConfig(project, serviceName string, client *aiven.Client, modifiers ...func(*aiven.ElasticSearchACLConfig)) error {
-	resourceElasticsearchACLModifierMutex.Lock()
-	defer resourceElasticsearchACLModifierMutex.Unlock()
+	mu := resourceElasticsearchACLServiceMutex(project, serviceName)
+	mu.Lock()
+	defer mu.Unlock()
 
 	r, err := client.ElasticsearchACLs.Get(project, serviceName)
 	if err != nil {
 		return err
 	}
+	remote := r.ElasticSearchACLConfig
+
+	var changes []string
+	for attempt := 0; attempt < resourceElasticsearchACLModifyRetries; attempt++ {
+		config := copyElasticsearchACLConfig(remote)
+		for i := range modifiers {
+			modifiers[i](&config)
+		}
+
+		r, err := client.ElasticsearchACLs.Get(project, serviceName)
+		if err != nil {
+			return err
+		}
+
+		changes = diffElasticsearchACLConfigs(remote, r.ElasticSearchACLConfig)
+		if len(changes) == 0 {
+			_, err = client.ElasticsearchACLs.Update(
+				project,
+				serviceName,
+				aiven.ElasticsearchACLRequest{ElasticSearchACLConfig: config})
 
-	config := r.ElasticSearchACLConfig
-	for i := range modifiers {
-		modifiers[i](&config)
+			return err
+		}
+
+		log.Printf("[DEBUG] ACL config of %s/%s was changed concurrently, applying the change to the new config: %s",
+			project, serviceName, strings.Join(changes, ", "))
+		remote = r.ElasticSearchACLConfig
 	}
 
-	_, err = client.ElasticsearchACLs.Update(
-		project,
-		serviceName,
-		aiven.ElasticsearchACLRequest{ElasticSearchACLConfig: config})
-	if err != nil {
-		return err
+	return fmt.Errorf("ACL config of service %s/%s keeps being changed concurrently, last change: %s",
+		project, serviceName, strings.Join(changes, ", "))
+}
+
+// copyElasticsearchACLConfig deep copies the config, modifiers change the slices in place
+func copyElasticsearchACLConfig(cfg aiven.ElasticSearchACLConfig) aiven.ElasticSearchACLConfig {
+	c := cfg
+	c.ACLs = make([]aiven.ElasticSearchACL, len(cfg.ACLs))
+	for i, acl := range cfg.ACLs {
+		c.ACLs[i] = aiven.ElasticSearchACL{
+			Username: acl.Username,
+			Rules:    append([]aiven.ElasticsearchACLRule(nil), acl.Rules...),
+		}
 	}
-	return nil
+
+	return c
+}
+
+// flattenElasticsearchACLConfig lists the effective ACL rules as sorted
+// `username:index:permission` strings
+func flattenElasticsearchACLConfig(cfg aiven.ElasticSearchACLConfig) []string {
+	var rules []string
+	for _, acl := range cfg.ACLs {
+		for _, rule := range acl.Rules {
+			rules = append(rules, fmt.Sprintf("%s:%s:%s", acl.Username, rule.Index, rule.Permission))
+		}
+	}
+	sort.Strings(rules)
+
+	return rules
+}
+
+// diffElasticsearchACLConfigs describes the differences between two ACL configs,
+// an empty result means the configs are equal
+func diffElasticsearchACLConfigs(before, after aiven.ElasticSearchACLConfig) []string {
+	var changes []string
+	if before.Enabled != after.Enabled {
+		changes = append(changes, fmt.Sprintf("enabled %t -> %t", before.Enabled, after.Enabled))
+	}
+	if before.ExtendedAcl != after.ExtendedAcl {
+		changes = append(changes, fmt.Sprintf("extended_acl %t -> %t", before.ExtendedAcl, after.ExtendedAcl))
+	}
+
+	beforeRules := make(map[string]bool)
+	for _, r := range flattenElasticsearchACLConfig(before) {
+		beforeRules[r] = true
+	}
+	afterRules := make(map[string]bool)
+	for _, r := range flattenElasticsearchACLConfig(after) {
+		afterRules[r] = true
+		if !beforeRules[r] {
+			changes = append(changes, "+"+r)
+		}
+	}
+	for _, r := range flattenElasticsearchACLConfig(before) {
+		if !afterRules[r] {
+			changes = append(changes, "-"+r)
+		}
+	}
+
+	return changes
 }
 
 // some modifiers
@@ -0,0 +1,142 @@
+package aiven
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+func testElasticsearchACLConfig(rules ...string) aiven.ElasticSearchACLConfig {
+	cfg := aiven.ElasticSearchACLConfig{Enabled: true, ExtendedAcl: true}
+	for i := 0; i+2 < len(rules); i += 3 {
+		cfg.Add(resourceElasticsearchACLRuleMkAivenACL(rules[i], rules[i+1], rules[i+2]))
+	}
+
+	return cfg
+}
+
+func Test_diffElasticsearchACLConfigs(t *testing.T) {
+	before := testElasticsearchACLConfig("user1", "logs-*", "read", "user2", "*", "admin")
+	after := testElasticsearchACLConfig("user2", "*", "admin", "user3", "metrics-*", "write")
+	after.Enabled = false
+
+	want := []string{"enabled true -> false", "+user3:metrics-*:write", "-user1:logs-*:read"}
+	if got := diffElasticsearchACLConfigs(before, after); !reflect.DeepEqual(got, want) {
+		t.Errorf("diffElasticsearchACLConfigs() = %v, want %v", got, want)
+	}
+
+	if got := diffElasticsearchACLConfigs(before, copyElasticsearchACLConfig(before)); len(got) != 0 {
+		t.Errorf("diffElasticsearchACLConfigs() of equal configs = %v", got)
+	}
+}
+
+func Test_copyElasticsearchACLConfig(t *testing.T) {
+	original := testElasticsearchACLConfig("user1", "a", "read", "user1", "b", "write", "user2", "c", "admin")
+	want := flattenElasticsearchACLConfig(original)
+
+	c := copyElasticsearchACLConfig(original)
+	resourceElasticsearchACLModifierDeleteACLRule("user1", "a", "read")(&c)
+	resourceElasticsearchACLModifierUpdateACLRule("user2", "c", "deny")(&c)
+
+	if got := flattenElasticsearchACLConfig(original); !reflect.DeepEqual(got, want) {
+		t.Errorf("modifying a copy changed the original config to %v, want %v", got, want)
+	}
+}
+
+// elasticsearchACLRoundTripper serves the given GET responses in order and records PUT requests
+type elasticsearchACLRoundTripper struct {
+	gets []aiven.ElasticSearchACLConfig
+	puts []aiven.ElasticSearchACLConfig
+}
+
+func (rt *elasticsearchACLRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	var cfg aiven.ElasticSearchACLConfig
+	if req.Method == http.MethodPut {
+		var body aiven.ElasticsearchACLRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			return nil, err
+		}
+		rt.puts = append(rt.puts, body.ElasticSearchACLConfig)
+		cfg = body.ElasticSearchACLConfig
+	} else {
+		cfg, rt.gets = rt.gets[0], rt.gets[1:]
+	}
+
+	bts, err := json.Marshal(aiven.ElasticSearchACLResponse{ElasticSearchACLConfig: cfg})
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBuffer(bts)),
+		Request:    req,
+	}, nil
+}
+
+func Test_resourceElasticsearchACLModifyRemoteConfig(t *testing.T) {
+	initial := testElasticsearchACLConfig("user1", "a", "read")
+	modifier := resourceElasticsearchACLModifierUpdateACLRule("user2", "c", "write")
+
+	t.Run("writes once without concurrent changes", func(t *testing.T) {
+		rt := &elasticsearchACLRoundTripper{gets: []aiven.ElasticSearchACLConfig{initial, initial}}
+		client, _ := aiven.NewTokenClient("token", "test")
+		client.Client = &http.Client{Transport: rt}
+
+		if err := resourceElasticsearchACLModifyRemoteConfig("project", "service", client, modifier); err != nil {
+			t.Fatalf("resourceElasticsearchACLModifyRemoteConfig() error = %v", err)
+		}
+
+		if len(rt.puts) != 1 {
+			t.Fatalf("expected 1 PUT request, got %d", len(rt.puts))
+		}
+		want := []string{"user1:a:read", "user2:c:write"}
+		if got := flattenElasticsearchACLConfig(rt.puts[0]); !reflect.DeepEqual(got, want) {
+			t.Errorf("PUT config = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("keeps a change made between the GET and the PUT", func(t *testing.T) {
+		// another writer added a rule after the first GET
+		concurrent := testElasticsearchACLConfig("user1", "a", "read", "other", "b", "admin")
+		rt := &elasticsearchACLRoundTripper{gets: []aiven.ElasticSearchACLConfig{initial, concurrent, concurrent}}
+		client, _ := aiven.NewTokenClient("token", "test")
+		client.Client = &http.Client{Transport: rt}
+
+		if err := resourceElasticsearchACLModifyRemoteConfig("project", "service", client, modifier); err != nil {
+			t.Fatalf("resourceElasticsearchACLModifyRemoteConfig() error = %v", err)
+		}
+
+		if len(rt.puts) != 1 {
+			t.Fatalf("expected 1 PUT request, got %d", len(rt.puts))
+		}
+		want := []string{"other:b:admin", "user1:a:read", "user2:c:write"}
+		if got := flattenElasticsearchACLConfig(rt.puts[0]); !reflect.DeepEqual(got, want) {
+			t.Errorf("PUT config = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("fails when the config keeps changing", func(t *testing.T) {
+		var gets []aiven.ElasticSearchACLConfig
+		for i := 0; i <= resourceElasticsearchACLModifyRetries; i++ {
+			gets = append(gets, testElasticsearchACLConfig("user1", "a", "read", "other", string(rune('a'+i)), "admin"))
+		}
+		rt := &elasticsearchACLRoundTripper{gets: gets}
+		client, _ := aiven.NewTokenClient("token", "test")
+		client.Client = &http.Client{Transport: rt}
+
+		err := resourceElasticsearchACLModifyRemoteConfig("project", "service", client, modifier)
+		if err == nil || !strings.Contains(err.Error(), "+other:d:admin") {
+			t.Errorf("resourceElasticsearchACLModifyRemoteConfig() expected a conflict error with the change, got %v", err)
+		}
+		if len(rt.puts) != 0 {
+			t.Errorf("expected no PUT requests, got %d", len(rt.puts))
+		}
+	})
+}
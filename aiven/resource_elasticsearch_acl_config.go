@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -34,6 +33,12 @@ var aivenElasticsearchACLConfigSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Default:     true,
 	},
+	"effective_acls": {
+		Type:        schema.TypeList,
+		Description: "Effective ACL rules of the service including rules managed outside of this resource, formatted as `username:index:permission`",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
 }
 
 func resourceElasticsearchACLConfig() *schema.Resource {
@@ -51,7 +56,7 @@ func resourceElasticsearchACLConfig() *schema.Resource {
 }
 
 func resourceElasticsearchACLConfigRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName := splitResourceID2(d.Id())
 	r, err := client.ElasticsearchACLs.Get(project, serviceName)
@@ -71,6 +76,9 @@ func resourceElasticsearchACLConfigRead(_ context.Context, d *schema.ResourceDat
 	if err := d.Set("enabled", r.ElasticSearchACLConfig.Enabled); err != nil {
 		return diag.Errorf("error setting ACLs `enable` for resource %s: %s", d.Id(), err)
 	}
+	if err := d.Set("effective_acls", flattenElasticsearchACLConfig(r.ElasticSearchACLConfig)); err != nil {
+		return diag.Errorf("error setting ACLs `effective_acls` for resource %s: %s", d.Id(), err)
+	}
 	return nil
 }
 
@@ -84,7 +92,7 @@ func resourceElasticsearchACLConfigState(ctx context.Context, d *schema.Resource
 }
 
 func resourceElasticsearchACLConfigUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -101,7 +109,7 @@ func resourceElasticsearchACLConfigUpdate(ctx context.Context, d *schema.Resourc
 }
 
 func resourceElasticsearchACLConfigDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
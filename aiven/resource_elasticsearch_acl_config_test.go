@@ -64,7 +64,7 @@ func testAccElasticsearchACLConfigResource(name string) string {
 }
 
 func testAccCheckAivenElasticsearchACLConfigResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each ES ACL Config is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -113,7 +113,7 @@ func testAccCheckAivenElasticsearchAclAttributes(n string) resource.TestCheckFun
 }
 
 func testAccCheckAivenAleasticsearchAclResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each ES ACL is destroyed
 	for _, rs := range s.RootModule().Resources {
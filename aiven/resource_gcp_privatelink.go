@@ -0,0 +1,136 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// gcpPrivatelink is the Private Service Connect service attachment of an Aiven service
+type gcpPrivatelink struct {
+	aiven.APIResponse
+	GoogleServiceAttachment string `json:"google_service_attachment"`
+	State                   string `json:"state"`
+}
+
+var gcpPrivatelinkCloud = privatelinkCloud{
+	name:                "google",
+	title:               "Google Cloud",
+	endpointKey:         "psc_connection_id",
+	endpointDescription: "Private Service Connect connection ID of the endpoint",
+	approveWithIP:       true,
+}
+
+var aivenGCPPrivatelinkSchema = privatelinkSchema(map[string]*schema.Schema{
+	"google_service_attachment": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Google Private Service Connect service attachment",
+	},
+	"state": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Privatelink resource state",
+	},
+})
+
+func resourceGCPPrivatelink() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGCPPrivatelinkCreate,
+		ReadContext:   resourceGCPPrivatelinkRead,
+		DeleteContext: resourceGCPPrivatelinkDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: privatelinkImportState(resourceGCPPrivatelinkRead, "Google Cloud"),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: aivenGCPPrivatelinkSchema,
+	}
+}
+
+func getGCPPrivatelink(ctx context.Context, client *aiven.Client, project, serviceName string) (*gcpPrivatelink, error) {
+	var p gcpPrivatelink
+	if err := aivenAPIRequest(ctx, client, http.MethodGet, privatelinkPath(project, serviceName, "google"), nil, &p); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func resourceGCPPrivatelinkCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	project := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+
+	err := aivenAPIRequest(ctx, client, http.MethodPost, privatelinkPath(project, serviceName, "google"), struct{}{}, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	w := &PrivatelinkWaiter{
+		Cloud: "Google Cloud",
+		Get: func() (interface{}, string, error) {
+			p, err := getGCPPrivatelink(ctx, client, project, serviceName)
+			if err != nil {
+				return nil, "", err
+			}
+			return p, p.State, nil
+		},
+	}
+
+	if _, err := w.Conf(d.Timeout(schema.TimeoutCreate)).WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("Error waiting for Google Cloud privatelink creation: %s", err)
+	}
+
+	d.SetId(buildResourceID(project, serviceName))
+
+	return resourceGCPPrivatelinkRead(ctx, d, m)
+}
+
+func resourceGCPPrivatelinkRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName := splitResourceID2(d.Id())
+	p, err := getGCPPrivatelink(ctx, m.(*providerMeta).client, project, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("google_service_attachment", p.GoogleServiceAttachment); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("state", p.State); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGCPPrivatelinkDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	project, serviceName := splitResourceID2(d.Id())
+
+	err := aivenAPIRequest(ctx, m.(*providerMeta).client, http.MethodDelete, privatelinkPath(project, serviceName, "google"), nil, nil)
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func datasourceGCPPrivatelink() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: privatelinkDatasourceRead(resourceGCPPrivatelinkRead),
+		Schema:      resourceSchemaAsDatasourceSchema(aivenGCPPrivatelinkSchema, "project", "service_name"),
+	}
+}
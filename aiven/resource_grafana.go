@@ -9,6 +9,7 @@ import (
 
 func grafanaSchema() map[string]*schema.Schema {
 	s := serviceCommonSchema()
+	s["restore_from"] = serviceRestoreFromSchema()
 	s[ServiceTypeGrafana] = &schema.Schema{
 		Type:        schema.TypeList,
 		MaxItems:    1,
@@ -43,6 +44,7 @@ func resourceGrafana() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeGrafana),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
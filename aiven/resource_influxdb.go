@@ -9,6 +9,7 @@ import (
 
 func influxDBSchema() map[string]*schema.Schema {
 	s := serviceCommonSchema()
+	s["restore_from"] = serviceRestoreFromSchema()
 	s[ServiceTypeInfluxDB] = &schema.Schema{
 		Type:        schema.TypeList,
 		MaxItems:    1,
@@ -49,6 +50,7 @@ func resourceInfluxDB() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeInfluxDB),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
@@ -12,6 +12,7 @@ import (
 
 func aivenKafkaSchema() map[string]*schema.Schema {
 	aivenKafkaSchema := serviceCommonSchema()
+	aivenKafkaSchema["allow_major_upgrade"] = serviceAllowMajorUpgradeSchema()
 	aivenKafkaSchema["default_acl"] = &schema.Schema{
 		Type:        schema.TypeBool,
 		Optional:    true,
@@ -88,6 +89,7 @@ func resourceKafka() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeKafka),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
@@ -104,7 +106,7 @@ func resourceKafkaCreate(ctx context.Context, d *schema.ResourceData, m interfac
 
 	// if default_acl=false delete default wildcard Kafka ACL that is automatically created
 	if !d.Get("default_acl").(bool) {
-		client := m.(*aiven.Client)
+		client := m.(*providerMeta).client
 		project := d.Get("project").(string)
 		serviceName := d.Get("service_name").(string)
 
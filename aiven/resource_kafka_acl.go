@@ -68,7 +68,7 @@ func resourceKafkaACL() *schema.Resource {
 }
 
 func resourceKafkaACLCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -92,7 +92,7 @@ func resourceKafkaACLCreate(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceKafkaACLRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, serviceName, aclID := splitResourceID3(d.Id())
 	acl, err := cache.ACLCache{}.Read(project, serviceName, aclID, client)
@@ -109,7 +109,7 @@ func resourceKafkaACLRead(_ context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceKafkaACLDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, aclID := splitResourceID3(d.Id())
 	err := client.KafkaACLs.Delete(projectName, serviceName, aclID)
@@ -239,7 +239,7 @@ func testAccKafkaACLResource(name string) string {
 }
 
 func testAccCheckAivenKafkaACLResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each kafka ACL is destroyed
 	for _, rs := range s.RootModule().Resources {
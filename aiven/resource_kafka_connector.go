@@ -128,7 +128,7 @@ func resourceKafkaConnectorRead(ctx context.Context, d *schema.ResourceData, m i
 		Pending: []string{"IN_PROGRESS"},
 		Target:  []string{"OK"},
 		Refresh: func() (interface{}, string, error) {
-			list, err := m.(*aiven.Client).KafkaConnectors.List(project, serviceName)
+			list, err := m.(*providerMeta).client.KafkaConnectors.List(project, serviceName)
 			if err != nil {
 				log.Printf("[DEBUG] Kafka Connectors list waiter err %s", err.Error())
 				if aiven.IsNotFound(err) {
@@ -208,7 +208,7 @@ func resourceKafkaConnectorCreate(ctx context.Context, d *schema.ResourceData, m
 		config[k] = cS.(string)
 	}
 
-	err := m.(*aiven.Client).KafkaConnectors.Create(project, serviceName, config)
+	err := m.(*providerMeta).client.KafkaConnectors.Create(project, serviceName, config)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -219,7 +219,7 @@ func resourceKafkaConnectorCreate(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceKafkaConnectorDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	err := m.(*aiven.Client).KafkaConnectors.Delete(splitResourceID3(d.Id()))
+	err := m.(*providerMeta).client.KafkaConnectors.Delete(splitResourceID3(d.Id()))
 	if err != nil && !aiven.IsNotFound(err) {
 		return diag.FromErr(err)
 	}
@@ -235,7 +235,7 @@ func resourceKafkaTConnectorUpdate(ctx context.Context, d *schema.ResourceData,
 		config[k] = cS.(string)
 	}
 
-	_, err := m.(*aiven.Client).KafkaConnectors.Update(project, serviceName, connectorName, config)
+	_, err := m.(*providerMeta).client.KafkaConnectors.Update(project, serviceName, connectorName, config)
 	if err != nil {
 		return diag.FromErr(err)
 	}
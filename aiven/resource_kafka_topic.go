@@ -82,6 +82,11 @@ var aivenKafkaTopicSchema = map[string]*schema.Schema{
 			topic from being deleted. It is recommended to enable this for any production Kafka 
 			topic containing critical data.`,
 	},
+	"prevent_destroy_reason": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Reason shown when termination_protection prevents deleting or replacing the topic",
+	},
 	"tag": {
 		Type:        schema.TypeSet,
 		Description: "Kafka Topic tag",
@@ -274,6 +279,8 @@ func resourceKafkaTopic() *schema.Resource {
 			Read:   schema.DefaultTimeout(10 * time.Minute),
 			Delete: schema.DefaultTimeout(2 * time.Minute),
 		},
+		CustomizeDiff: resourceTerminationProtectionCustomizeDiff("kafka topic", "topic_name", aivenKafkaTopicSchema),
+
 		Schema: aivenKafkaTopicSchema,
 	}
 }
@@ -298,7 +305,7 @@ func resourceKafkaTopicCreate(ctx context.Context, d *schema.ResourceData, m int
 	}
 
 	w := &KafkaTopicCreateWaiter{
-		Client:        m.(*aiven.Client),
+		Client:        m.(*providerMeta).client,
 		Project:       project,
 		ServiceName:   serviceName,
 		CreateRequest: createRequest,
@@ -455,7 +462,7 @@ func getTopic(ctx context.Context, d *schema.ResourceData, m interface{}, ignore
 	project, serviceName, topicName := splitResourceID3(d.Id())
 
 	w := &KafkaTopicAvailabilityWaiter{
-		Client:      m.(*aiven.Client),
+		Client:      m.(*providerMeta).client,
 		Project:     project,
 		ServiceName: serviceName,
 		TopicName:   topicName,
@@ -472,7 +479,7 @@ func getTopic(ctx context.Context, d *schema.ResourceData, m interface{}, ignore
 }
 
 func resourceKafkaTopicUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	partitions := d.Get("partitions").(int)
 	projectName, serviceName, topicName := splitResourceID3(d.Id())
@@ -498,12 +505,12 @@ func resourceKafkaTopicUpdate(_ context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceKafkaTopicDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, topicName := splitResourceID3(d.Id())
 
 	if d.Get("termination_protection").(bool) {
-		return diag.Errorf("cannot delete kafka topic when termination_protection is enabled")
+		return diag.FromErr(terminationProtectionError("kafka topic", topicName, d.Get("prevent_destroy_reason").(string)))
 	}
 
 	waiter := KafkaTopicDeleteWaiter{
@@ -578,10 +585,12 @@ type KafkaTopicDeleteWaiter struct {
 func (w *KafkaTopicDeleteWaiter) RefreshFunc() resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		err := w.Client.KafkaTopics.Delete(w.ProjectName, w.ServiceName, w.TopicName)
-		if err != nil {
-			if !aiven.IsNotFound(err) {
+		if err != nil && !aiven.IsNotFound(err) {
+			if isRetryableDeleteError(err) {
+				log.Printf("[DEBUG] retrying delete of kafka topic %s: %s", w.TopicName, err)
 				return nil, "REMOVING", nil
 			}
+			return nil, "", err
 		}
 
 		return aiven.KafkaTopic{}, "DELETED", nil
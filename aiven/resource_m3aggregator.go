@@ -9,6 +9,7 @@ import (
 
 func aivenM3AggregatorSchema() map[string]*schema.Schema {
 	schemaM3 := serviceCommonSchema()
+	schemaM3["allow_major_upgrade"] = serviceAllowMajorUpgradeSchema()
 	schemaM3[ServiceTypeM3Aggregator] = &schema.Schema{
 		Type:        schema.TypeList,
 		MaxItems:    1,
@@ -42,6 +43,7 @@ func resourceM3Aggregator() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeM3Aggregator),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
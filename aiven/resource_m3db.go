@@ -9,6 +9,8 @@ import (
 
 func aivenM3DBSchema() map[string]*schema.Schema {
 	schemaM3 := serviceCommonSchema()
+	schemaM3["allow_major_upgrade"] = serviceAllowMajorUpgradeSchema()
+	schemaM3["restore_from"] = serviceRestoreFromSchema()
 	schemaM3[ServiceTypeM3] = &schema.Schema{
 		Type:        schema.TypeList,
 		MaxItems:    1,
@@ -42,6 +44,7 @@ func resourceM3DB() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeM3),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
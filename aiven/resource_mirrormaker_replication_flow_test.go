@@ -36,7 +36,7 @@ func TestAccAivenMirrorMakerReplicationFlow_basic(t *testing.T) {
 }
 
 func testAccCheckAivenMirrorMakerReplicationFlowResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each kafka mirror maker
 	// replication flow is destroyed
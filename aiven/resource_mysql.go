@@ -9,6 +9,8 @@ import (
 
 func aivenMySQLSchema() map[string]*schema.Schema {
 	schemaMySQL := serviceCommonSchema()
+	schemaMySQL["allow_major_upgrade"] = serviceAllowMajorUpgradeSchema()
+	schemaMySQL["restore_from"] = serviceRestoreFromSchema()
 	schemaMySQL[ServiceTypeMySQL] = &schema.Schema{
 		Type:        schema.TypeList,
 		MaxItems:    1,
@@ -42,6 +44,7 @@ func resourceMySQL() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeMySQL),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
@@ -0,0 +1,183 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenMySQLGrantSchema = map[string]*schema.Schema{
+	"project": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Project the MySQL service belongs to",
+		ForceNew:    true,
+	},
+	"service_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "MySQL service the database belongs to",
+		ForceNew:    true,
+	},
+	"username": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Service user to grant the privileges to",
+		ForceNew:    true,
+	},
+	"database_name": {
+		Type:         schema.TypeString,
+		Required:     true,
+		Description:  "Database to grant the privileges on",
+		ForceNew:     true,
+		ValidateFunc: validation.StringNotInSlice([]string{"*"}, false),
+	},
+	"table": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Default:     "*",
+		Description: "Table to grant the privileges on, * grants them on the whole database",
+		ForceNew:    true,
+	},
+	"privileges": {
+		Type:        schema.TypeSet,
+		Required:    true,
+		MinItems:    1,
+		Description: "Privileges to grant, like SELECT or INSERT",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	},
+}
+
+func resourceMySQLGrant() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceMySQLGrantCreate,
+		ReadContext:   resourceMySQLGrantRead,
+		UpdateContext: resourceMySQLGrantUpdate,
+		DeleteContext: resourceMySQLGrantDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceMySQLGrantState,
+		},
+		CustomizeDiff: resourceMySQLGrantCustomizeDiff,
+
+		Schema: aivenMySQLGrantSchema,
+	}
+}
+
+func resourceMySQLGrantCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	return validateMySQLPrivileges(d.Get("table").(string), flattenToString(d.Get("privileges").(*schema.Set).List()))
+}
+
+// resourceMySQLGrantApply turns the current privileges of the user into the given ones
+func resourceMySQLGrantApply(ctx context.Context, d *schema.ResourceData, m interface{}, privileges []string) error {
+	db, err := mysqlConnect(ctx, m.(*providerMeta).client, d.Get("project").(string), d.Get("service_name").(string))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	databaseName := d.Get("database_name").(string)
+	table := d.Get("table").(string)
+	username := d.Get("username").(string)
+
+	current, err := readMySQLGrants(ctx, db, databaseName, table, username)
+	if err != nil {
+		return err
+	}
+
+	return mysqlExec(ctx, db, mysqlGrantStatements(databaseName, table, username, current, privileges)...)
+}
+
+func resourceMySQLGrantCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := resourceMySQLGrantApply(ctx, d, m, flattenToString(d.Get("privileges").(*schema.Set).List())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(
+		d.Get("project").(string),
+		d.Get("service_name").(string),
+		d.Get("username").(string),
+		d.Get("database_name").(string),
+		d.Get("table").(string),
+	))
+
+	return resourceMySQLGrantRead(ctx, d, m)
+}
+
+func resourceMySQLGrantUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := resourceMySQLGrantApply(ctx, d, m, flattenToString(d.Get("privileges").(*schema.Set).List())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceMySQLGrantRead(ctx, d, m)
+}
+
+func resourceMySQLGrantRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	parts := splitResourceID(d.Id(), 5)
+	projectName, serviceName, username, databaseName, table := parts[0], parts[1], parts[2], parts[3], parts[4]
+
+	db, err := mysqlConnect(ctx, m.(*providerMeta).client, projectName, serviceName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+	defer db.Close()
+
+	privileges, err := readMySQLGrants(ctx, db, databaseName, table, username)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(privileges) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("username", username); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database_name", databaseName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("table", table); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("privileges", privileges); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceMySQLGrantDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := resourceMySQLGrantApply(ctx, d, m, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceMySQLGrantState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if len(strings.Split(d.Id(), "/")) != 5 {
+		return nil, fmt.Errorf("invalid identifier %v, expected "+
+			"<project_name>/<service_name>/<username>/<database_name>/<table>", d.Id())
+	}
+
+	di := resourceMySQLGrantRead(ctx, d, m)
+	if di.HasError() {
+		return nil, fmt.Errorf("cannot get MySQL grant: %v", di)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
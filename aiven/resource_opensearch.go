@@ -10,6 +10,8 @@ import (
 
 func opensearchSchema() map[string]*schema.Schema {
 	s := serviceCommonSchema()
+	s["allow_major_upgrade"] = serviceAllowMajorUpgradeSchema()
+	s["restore_from"] = serviceRestoreFromSchema()
 	s[ServiceTypeOpensearch] = &schema.Schema{
 		Type:        schema.TypeList,
 		MaxItems:    1,
@@ -56,6 +58,7 @@ func resourceOpensearch() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeOpensearch),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
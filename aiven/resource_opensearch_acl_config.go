@@ -29,6 +29,12 @@ var aivenOpensearchACLConfigSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Default:     true,
 	},
+	"effective_acls": {
+		Type:        schema.TypeList,
+		Description: "Effective ACL rules of the service including rules managed outside of this resource, formatted as `username:index:permission`",
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	},
 }
 
 func resourceOpensearchACLConfig() *schema.Resource {
@@ -0,0 +1,64 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+type opensearchSecurityActionGroup struct {
+	Description    string   `json:"description,omitempty"`
+	Type           string   `json:"type,omitempty"`
+	AllowedActions []string `json:"allowed_actions"`
+}
+
+var opensearchSecurityActionGroupObject = opensearchSecurityObject{
+	kind:  "actiongroups",
+	title: "OpenSearch security action group",
+	expand: func(d *schema.ResourceData) interface{} {
+		return opensearchSecurityActionGroup{
+			Description:    d.Get("description").(string),
+			Type:           d.Get("type").(string),
+			AllowedActions: flattenToString(d.Get("allowed_actions").(*schema.Set).List()),
+		}
+	},
+	flatten: func(d *schema.ResourceData, body json.RawMessage) error {
+		var group opensearchSecurityActionGroup
+		if err := json.Unmarshal(body, &group); err != nil {
+			return err
+		}
+
+		if err := d.Set("description", group.Description); err != nil {
+			return err
+		}
+		if err := d.Set("type", group.Type); err != nil {
+			return err
+		}
+
+		return d.Set("allowed_actions", group.AllowedActions)
+	},
+}
+
+func resourceOpensearchSecurityActionGroup() *schema.Resource {
+	return resourceOpensearchSecurityObject(opensearchSecurityActionGroupObject, map[string]*schema.Schema{
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Description of the action group",
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Type of the actions of the group, one of cluster, index or kibana",
+			ValidateFunc: validation.StringInSlice([]string{"cluster", "index", "kibana"}, false),
+		},
+		"allowed_actions": {
+			Type:        schema.TypeSet,
+			Required:    true,
+			Description: "Actions or other action groups included in the group",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	})
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type opensearchSecurityIndexPermission struct {
+	IndexPatterns  []string `json:"index_patterns"`
+	DLS            string   `json:"dls,omitempty"`
+	FLS            []string `json:"fls"`
+	MaskedFields   []string `json:"masked_fields"`
+	AllowedActions []string `json:"allowed_actions"`
+}
+
+type opensearchSecurityTenantPermission struct {
+	TenantPatterns []string `json:"tenant_patterns"`
+	AllowedActions []string `json:"allowed_actions"`
+}
+
+type opensearchSecurityRole struct {
+	Description        string                               `json:"description,omitempty"`
+	ClusterPermissions []string                             `json:"cluster_permissions"`
+	IndexPermissions   []opensearchSecurityIndexPermission  `json:"index_permissions"`
+	TenantPermissions  []opensearchSecurityTenantPermission `json:"tenant_permissions"`
+}
+
+var opensearchSecurityRoleObject = opensearchSecurityObject{
+	kind:    "roles",
+	title:   "OpenSearch security role",
+	expand:  expandOpensearchSecurityRole,
+	flatten: flattenOpensearchSecurityRole,
+}
+
+func resourceOpensearchSecurityRole() *schema.Resource {
+	return resourceOpensearchSecurityObject(opensearchSecurityRoleObject, map[string]*schema.Schema{
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Description of the role",
+		},
+		"cluster_permissions": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Cluster wide permissions, actions or action groups",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"index_permissions": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Permissions on indexes with document and field level security",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"index_patterns": {
+						Type:        schema.TypeList,
+						Required:    true,
+						Description: "Index patterns the permission applies to",
+						Elem:        opensearchIndexPatternSchema(),
+					},
+					"dls": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Document level security query limiting the documents the role can read",
+					},
+					"fls": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Description: "Field level security, fields the role can read or, prefixed with ~, cannot read",
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+					"masked_fields": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Description: "Fields whose values are anonymized",
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+					"allowed_actions": {
+						Type:        schema.TypeList,
+						Required:    true,
+						Description: "Actions or action groups allowed on the indexes",
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+		"tenant_permissions": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "Permissions on OpenSearch Dashboards tenants",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"tenant_patterns": {
+						Type:        schema.TypeList,
+						Required:    true,
+						Description: "Tenant patterns the permission applies to",
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+					"allowed_actions": {
+						Type:        schema.TypeList,
+						Required:    true,
+						Description: "Actions allowed on the tenants, e.g. kibana_all_read or kibana_all_write",
+						Elem:        &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+	})
+}
+
+func expandOpensearchSecurityRole(d *schema.ResourceData) interface{} {
+	role := opensearchSecurityRole{
+		Description:        d.Get("description").(string),
+		ClusterPermissions: flattenToString(d.Get("cluster_permissions").(*schema.Set).List()),
+		IndexPermissions:   []opensearchSecurityIndexPermission{},
+		TenantPermissions:  []opensearchSecurityTenantPermission{},
+	}
+
+	for _, v := range d.Get("index_permissions").([]interface{}) {
+		p := v.(map[string]interface{})
+		role.IndexPermissions = append(role.IndexPermissions, opensearchSecurityIndexPermission{
+			IndexPatterns:  flattenToString(p["index_patterns"].([]interface{})),
+			DLS:            p["dls"].(string),
+			FLS:            flattenToString(p["fls"].([]interface{})),
+			MaskedFields:   flattenToString(p["masked_fields"].([]interface{})),
+			AllowedActions: flattenToString(p["allowed_actions"].([]interface{})),
+		})
+	}
+
+	for _, v := range d.Get("tenant_permissions").([]interface{}) {
+		p := v.(map[string]interface{})
+		role.TenantPermissions = append(role.TenantPermissions, opensearchSecurityTenantPermission{
+			TenantPatterns: flattenToString(p["tenant_patterns"].([]interface{})),
+			AllowedActions: flattenToString(p["allowed_actions"].([]interface{})),
+		})
+	}
+
+	return role
+}
+
+func flattenOpensearchSecurityRole(d *schema.ResourceData, body json.RawMessage) error {
+	var role opensearchSecurityRole
+	if err := json.Unmarshal(body, &role); err != nil {
+		return err
+	}
+
+	var indexPermissions []map[string]interface{}
+	for _, p := range role.IndexPermissions {
+		indexPermissions = append(indexPermissions, map[string]interface{}{
+			"index_patterns":  p.IndexPatterns,
+			"dls":             p.DLS,
+			"fls":             p.FLS,
+			"masked_fields":   p.MaskedFields,
+			"allowed_actions": p.AllowedActions,
+		})
+	}
+
+	var tenantPermissions []map[string]interface{}
+	for _, p := range role.TenantPermissions {
+		tenantPermissions = append(tenantPermissions, map[string]interface{}{
+			"tenant_patterns": p.TenantPatterns,
+			"allowed_actions": p.AllowedActions,
+		})
+	}
+
+	if err := d.Set("description", role.Description); err != nil {
+		return err
+	}
+	if err := d.Set("cluster_permissions", role.ClusterPermissions); err != nil {
+		return err
+	}
+	if err := d.Set("index_permissions", indexPermissions); err != nil {
+		return err
+	}
+
+	return d.Set("tenant_permissions", tenantPermissions)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type opensearchSecurityRoleMapping struct {
+	Description  string   `json:"description,omitempty"`
+	BackendRoles []string `json:"backend_roles"`
+	Hosts        []string `json:"hosts"`
+	Users        []string `json:"users"`
+}
+
+var opensearchSecurityRoleMappingObject = opensearchSecurityObject{
+	kind:  "rolesmapping",
+	title: "OpenSearch security role mapping",
+	expand: func(d *schema.ResourceData) interface{} {
+		return opensearchSecurityRoleMapping{
+			Description:  d.Get("description").(string),
+			BackendRoles: flattenToString(d.Get("backend_roles").(*schema.Set).List()),
+			Hosts:        flattenToString(d.Get("hosts").(*schema.Set).List()),
+			Users:        flattenToString(d.Get("users").(*schema.Set).List()),
+		}
+	},
+	flatten: func(d *schema.ResourceData, body json.RawMessage) error {
+		var mapping opensearchSecurityRoleMapping
+		if err := json.Unmarshal(body, &mapping); err != nil {
+			return err
+		}
+
+		if err := d.Set("description", mapping.Description); err != nil {
+			return err
+		}
+		if err := d.Set("backend_roles", mapping.BackendRoles); err != nil {
+			return err
+		}
+		if err := d.Set("hosts", mapping.Hosts); err != nil {
+			return err
+		}
+
+		return d.Set("users", mapping.Users)
+	},
+}
+
+func resourceOpensearchSecurityRoleMapping() *schema.Resource {
+	return resourceOpensearchSecurityObject(opensearchSecurityRoleMappingObject, map[string]*schema.Schema{
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Description of the role mapping",
+		},
+		"backend_roles": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Backend roles, e.g. SAML or LDAP groups, mapped to the role",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"hosts": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Hosts mapped to the role",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"users": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Users mapped to the role",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	})
+}
@@ -0,0 +1,213 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenPGDefaultPrivilegesSchema = map[string]*schema.Schema{
+	"project": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Project the PostgreSQL service belongs to",
+		ForceNew:    true,
+	},
+	"service_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "PostgreSQL service the objects are created in",
+		ForceNew:    true,
+	},
+	"database_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Database the objects are created in",
+		ForceNew:    true,
+	},
+	"username": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Service user or role to grant the privileges to",
+		ForceNew:    true,
+	},
+	"owner": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Role creating the objects, defaults to the service admin user",
+		ForceNew:    true,
+	},
+	"object_type": {
+		Type:         schema.TypeString,
+		Required:     true,
+		Description:  "Type of the objects, one of table, sequence, function or type",
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice([]string{"table", "sequence", "function", "type"}, false),
+	},
+	"schema": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Schema the objects are created in, when not set the privileges apply in all schemas",
+		ForceNew:    true,
+	},
+	"privileges": {
+		Type:        schema.TypeSet,
+		Required:    true,
+		MinItems:    1,
+		Description: "Privileges to grant, like SELECT or USAGE",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	},
+}
+
+func resourcePGDefaultPrivileges() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePGDefaultPrivilegesCreate,
+		ReadContext:   resourcePGDefaultPrivilegesRead,
+		UpdateContext: resourcePGDefaultPrivilegesUpdate,
+		DeleteContext: resourcePGDefaultPrivilegesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePGDefaultPrivilegesState,
+		},
+		CustomizeDiff: resourcePGDefaultPrivilegesCustomizeDiff,
+
+		Schema: aivenPGDefaultPrivilegesSchema,
+	}
+}
+
+func resourcePGDefaultPrivilegesCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	return validatePGPrivileges(d.Get("object_type").(string), flattenToString(d.Get("privileges").(*schema.Set).List()))
+}
+
+// resourcePGDefaultPrivilegesApply replaces the default privileges of the user
+func resourcePGDefaultPrivilegesApply(ctx context.Context, d *schema.ResourceData, m interface{}, privileges []string) error {
+	db, err := pgConnect(ctx, m.(*providerMeta).client,
+		d.Get("project").(string), d.Get("service_name").(string), d.Get("database_name").(string))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	owner := d.Get("owner").(string)
+	if owner == "" {
+		if err := db.QueryRowContext(ctx, "SELECT current_user").Scan(&owner); err != nil {
+			return err
+		}
+
+		if err := d.Set("owner", owner); err != nil {
+			return err
+		}
+	}
+
+	return pgExec(ctx, db, pgDefaultPrivilegesStatements(
+		owner,
+		d.Get("schema").(string),
+		d.Get("object_type").(string),
+		privileges,
+		d.Get("username").(string),
+	)...)
+}
+
+func resourcePGDefaultPrivilegesCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := resourcePGDefaultPrivilegesApply(ctx, d, m, flattenToString(d.Get("privileges").(*schema.Set).List())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(
+		d.Get("project").(string),
+		d.Get("service_name").(string),
+		d.Get("database_name").(string),
+		d.Get("username").(string),
+		d.Get("owner").(string),
+		d.Get("object_type").(string),
+		d.Get("schema").(string),
+	))
+
+	return resourcePGDefaultPrivilegesRead(ctx, d, m)
+}
+
+func resourcePGDefaultPrivilegesUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := resourcePGDefaultPrivilegesApply(ctx, d, m, flattenToString(d.Get("privileges").(*schema.Set).List())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePGDefaultPrivilegesRead(ctx, d, m)
+}
+
+func resourcePGDefaultPrivilegesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	parts := splitResourceID(d.Id(), 7)
+	projectName, serviceName, databaseName, username, owner, objectType, schemaName :=
+		parts[0], parts[1], parts[2], parts[3], parts[4], parts[5], parts[6]
+
+	db, err := pgConnect(ctx, m.(*providerMeta).client, projectName, serviceName, databaseName)
+	if err != nil {
+		return diag.FromErr(pgReadHandleNotFound(err, d))
+	}
+	defer db.Close()
+
+	privileges, err := readPGDefaultPrivileges(ctx, db, owner, schemaName, objectType, username)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if len(privileges) == 0 {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database_name", databaseName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("username", username); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("owner", owner); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("object_type", objectType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("schema", schemaName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("privileges", privileges); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcePGDefaultPrivilegesDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := resourcePGDefaultPrivilegesApply(ctx, d, m, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcePGDefaultPrivilegesState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if len(strings.Split(d.Id(), "/")) != 7 {
+		return nil, fmt.Errorf("invalid identifier %v, expected "+
+			"<project_name>/<service_name>/<database_name>/<username>/<owner>/<object_type>/<schema>", d.Id())
+	}
+
+	di := resourcePGDefaultPrivilegesRead(ctx, d, m)
+	if di.HasError() {
+		return nil, fmt.Errorf("cannot get PostgreSQL default privileges: %v", di)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lib/pq"
+)
+
+var aivenPGExtensionSchema = map[string]*schema.Schema{
+	"project": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Project the PostgreSQL service belongs to",
+		ForceNew:    true,
+	},
+	"service_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "PostgreSQL service to create the extension in",
+		ForceNew:    true,
+	},
+	"database_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Database to create the extension in",
+		ForceNew:    true,
+	},
+	"extension": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Name of the extension",
+		ForceNew:    true,
+	},
+	"schema": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Schema to install the objects of the extension into",
+		ForceNew:    true,
+	},
+	"version": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "Version of the extension, changing it updates the extension in place",
+	},
+}
+
+func resourcePGExtension() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePGExtensionCreate,
+		ReadContext:   resourcePGExtensionRead,
+		UpdateContext: resourcePGExtensionUpdate,
+		DeleteContext: resourcePGExtensionDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePGExtensionState,
+		},
+
+		Schema: aivenPGExtensionSchema,
+	}
+}
+
+func resourcePGExtensionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	projectName := d.Get("project").(string)
+	serviceName := d.Get("service_name").(string)
+	databaseName := d.Get("database_name").(string)
+	extension := d.Get("extension").(string)
+
+	db, err := pgConnect(ctx, m.(*providerMeta).client, projectName, serviceName, databaseName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	statement := "CREATE EXTENSION IF NOT EXISTS " + pq.QuoteIdentifier(extension)
+	if s := d.Get("schema").(string); s != "" {
+		statement += " SCHEMA " + pq.QuoteIdentifier(s)
+	}
+	if v := d.Get("version").(string); v != "" {
+		statement += " VERSION " + pq.QuoteLiteral(v)
+	}
+
+	if err := pgExec(ctx, db, statement); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(projectName, serviceName, databaseName, extension))
+
+	return resourcePGExtensionRead(ctx, d, m)
+}
+
+func resourcePGExtensionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	projectName, serviceName, databaseName, extension := splitResourceID4(d.Id())
+
+	db, err := pgConnect(ctx, m.(*providerMeta).client, projectName, serviceName, databaseName)
+	if err != nil {
+		return diag.FromErr(pgReadHandleNotFound(err, d))
+	}
+	defer db.Close()
+
+	var version, schemaName string
+	err = db.QueryRowContext(ctx, `SELECT e.extversion, n.nspname FROM pg_extension e
+		JOIN pg_namespace n ON n.oid = e.extnamespace WHERE e.extname = $1`, extension).Scan(&version, &schemaName)
+	if err == sql.ErrNoRows {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database_name", databaseName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("extension", extension); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("schema", schemaName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("version", version); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcePGExtensionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	projectName, serviceName, databaseName, extension := splitResourceID4(d.Id())
+
+	db, err := pgConnect(ctx, m.(*providerMeta).client, projectName, serviceName, databaseName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	statement := "ALTER EXTENSION " + pq.QuoteIdentifier(extension) + " UPDATE"
+	if v := d.Get("version").(string); v != "" {
+		statement += " TO " + pq.QuoteLiteral(v)
+	}
+
+	if err := pgExec(ctx, db, statement); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePGExtensionRead(ctx, d, m)
+}
+
+func resourcePGExtensionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	projectName, serviceName, databaseName, extension := splitResourceID4(d.Id())
+
+	db, err := pgConnect(ctx, m.(*providerMeta).client, projectName, serviceName, databaseName)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	defer db.Close()
+
+	if err := pgExec(ctx, db, "DROP EXTENSION IF EXISTS "+pq.QuoteIdentifier(extension)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcePGExtensionState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if len(strings.Split(d.Id(), "/")) != 4 {
+		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>/<database_name>/<extension>", d.Id())
+	}
+
+	di := resourcePGExtensionRead(ctx, d, m)
+	if di.HasError() {
+		return nil, fmt.Errorf("cannot get PostgreSQL extension: %v", di)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
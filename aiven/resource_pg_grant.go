@@ -0,0 +1,214 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var aivenPGGrantSchema = map[string]*schema.Schema{
+	"project": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Project the PostgreSQL service belongs to",
+		ForceNew:    true,
+	},
+	"service_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "PostgreSQL service the objects belong to",
+		ForceNew:    true,
+	},
+	"database_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Database the objects belong to",
+		ForceNew:    true,
+	},
+	"username": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Service user or role to grant the privileges to",
+		ForceNew:    true,
+	},
+	"object_type": {
+		Type:         schema.TypeString,
+		Required:     true,
+		Description:  "Type of the objects, one of schema, table, sequence or function",
+		ForceNew:     true,
+		ValidateFunc: validation.StringInSlice([]string{"schema", "table", "sequence", "function"}, false),
+	},
+	"schema": {
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Schema the objects belong to, or the schema itself when object_type is schema",
+		ForceNew:    true,
+	},
+	"objects": {
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "Names of the objects, when empty all existing objects of the type in the schema; cannot be set for schemas and functions",
+		ForceNew:    true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	},
+	"privileges": {
+		Type:        schema.TypeSet,
+		Required:    true,
+		MinItems:    1,
+		Description: "Privileges to grant, like SELECT or USAGE",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	},
+}
+
+func resourcePGGrant() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePGGrantCreate,
+		ReadContext:   resourcePGGrantRead,
+		UpdateContext: resourcePGGrantUpdate,
+		DeleteContext: resourcePGGrantDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcePGGrantState,
+		},
+		CustomizeDiff: resourcePGGrantCustomizeDiff,
+
+		Schema: aivenPGGrantSchema,
+	}
+}
+
+func resourcePGGrantCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	objectType := d.Get("object_type").(string)
+	if objectType == "schema" && d.Get("objects").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("objects cannot be set when object_type is schema")
+	}
+	// functions are identified by their argument types too, which objects cannot express
+	if objectType == "function" && d.Get("objects").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("objects cannot be set when object_type is function, the grant covers all functions of the schema")
+	}
+
+	return validatePGPrivileges(objectType, flattenToString(d.Get("privileges").(*schema.Set).List()))
+}
+
+// resourcePGGrantApply replaces the privileges of the user on the objects
+func resourcePGGrantApply(ctx context.Context, d *schema.ResourceData, m interface{}, privileges []string) error {
+	db, err := pgConnect(ctx, m.(*providerMeta).client,
+		d.Get("project").(string), d.Get("service_name").(string), d.Get("database_name").(string))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return pgExec(ctx, db, pgGrantStatements(
+		d.Get("object_type").(string),
+		d.Get("schema").(string),
+		flattenToString(d.Get("objects").(*schema.Set).List()),
+		privileges,
+		d.Get("username").(string),
+	)...)
+}
+
+func resourcePGGrantCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := resourcePGGrantApply(ctx, d, m, flattenToString(d.Get("privileges").(*schema.Set).List())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(
+		d.Get("project").(string),
+		d.Get("service_name").(string),
+		d.Get("database_name").(string),
+		d.Get("username").(string),
+		d.Get("object_type").(string),
+		d.Get("schema").(string),
+	))
+
+	return resourcePGGrantRead(ctx, d, m)
+}
+
+func resourcePGGrantUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := resourcePGGrantApply(ctx, d, m, flattenToString(d.Get("privileges").(*schema.Set).List())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourcePGGrantRead(ctx, d, m)
+}
+
+func resourcePGGrantRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	parts := splitResourceID(d.Id(), 6)
+	projectName, serviceName, databaseName, username, objectType, schemaName :=
+		parts[0], parts[1], parts[2], parts[3], parts[4], parts[5]
+
+	db, err := pgConnect(ctx, m.(*providerMeta).client, projectName, serviceName, databaseName)
+	if err != nil {
+		return diag.FromErr(pgReadHandleNotFound(err, d))
+	}
+	defer db.Close()
+
+	objects := flattenToString(d.Get("objects").(*schema.Set).List())
+	privileges, ok, err := readPGGrants(ctx, db, objectType, schemaName, objects, username)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !ok && (objectType == "schema" || len(objects) > 0) {
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", serviceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("database_name", databaseName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("username", username); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("object_type", objectType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("schema", schemaName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// an empty schema has no objects to read the privileges from
+	if ok {
+		if err := d.Set("privileges", privileges); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourcePGGrantDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := resourcePGGrantApply(ctx, d, m, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourcePGGrantState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if len(strings.Split(d.Id(), "/")) != 6 {
+		return nil, fmt.Errorf("invalid identifier %v, expected "+
+			"<project_name>/<service_name>/<database_name>/<username>/<object_type>/<schema>", d.Id())
+	}
+
+	di := resourcePGGrantRead(ctx, d, m)
+	if di.HasError() {
+		return nil, fmt.Errorf("cannot get PostgreSQL grant: %v", di)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -15,7 +15,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-var aivenProjectSchema = map[string]*schema.Schema{
+var aivenProjectSchema = tagsSchema(map[string]*schema.Schema{
 	"billing_address": {
 		Type:             schema.TypeString,
 		Description:      "Billing name and address of the project",
@@ -137,7 +137,7 @@ var aivenProjectSchema = map[string]*schema.Schema{
 		Description:      "Billing group Id",
 		DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 	},
-}
+}, "project")
 
 func resourceProject() *schema.Resource {
 	return &schema.Resource{
@@ -148,13 +148,14 @@ func resourceProject() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceProjectState,
 		},
+		CustomizeDiff: resourceTagsCustomizeDiff,
 
 		Schema: aivenProjectSchema,
 	}
 }
 
-func resourceProjectCreate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
 	cardID, err := getLongCardID(client, d.Get("card_id").(string))
 	if err != nil {
 		return diag.Errorf("Error getting long card id: %s", err)
@@ -224,6 +225,12 @@ func resourceProjectCreate(_ context.Context, d *schema.ResourceData, m interfac
 
 	d.SetId(projectName)
 
+	if d.Get("tag").(*schema.Set).Len() != 0 || len(m.(*providerMeta).defaultTags) != 0 {
+		if err := updateResourceTags(ctx, d, m, projectTagsPath(projectName)); err != nil {
+			return append(diags, diag.FromErr(err)...)
+		}
+	}
+
 	return append(diags, resourceProjectGetCACert(projectName, client, d)...)
 }
 
@@ -285,8 +292,8 @@ func resourceProjectAssignToBillingGroup(
 	return nil
 }
 
-func resourceProjectRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
 
 	project, err := client.Projects.Get(d.Id())
 	if err != nil {
@@ -334,11 +341,15 @@ func resourceProjectRead(_ context.Context, d *schema.ResourceData, m interface{
 		}
 	}
 
+	if err := readResourceTags(ctx, d, m, projectTagsPath(project.Name)); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+
 	return append(diags, setProjectTerraformProperties(d, client, project)...)
 }
 
-func resourceProjectUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+func resourceProjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
 
 	cardID, err := getLongCardID(client, d.Get("card_id").(string))
 	if err != nil {
@@ -396,11 +407,17 @@ func resourceProjectUpdate(_ context.Context, d *schema.ResourceData, m interfac
 
 	d.SetId(project.Name)
 
+	if d.HasChange("tag") || d.HasChange("tags_all") {
+		if err := updateResourceTags(ctx, d, m, projectTagsPath(project.Name)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return nil
 }
 
 func resourceProjectDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	err := client.Projects.Delete(d.Id())
 
@@ -425,7 +442,7 @@ func resourceProjectDelete(_ context.Context, d *schema.ResourceData, m interfac
 }
 
 func resourceProjectState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	project, err := client.Projects.Get(d.Id())
 	if err != nil {
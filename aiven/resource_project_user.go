@@ -9,8 +9,11 @@ import (
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var projectMemberTypes = []string{"admin", "developer", "operator", "read_only"}
+
 var aivenProjectUserSchema = map[string]*schema.Schema{
 	"project": {
 		Description: "The project the user belongs to",
@@ -25,15 +28,21 @@ var aivenProjectUserSchema = map[string]*schema.Schema{
 		Type:        schema.TypeString,
 	},
 	"member_type": {
-		Description: "Project membership type. One of: admin, developer, operator",
-		Required:    true,
-		Type:        schema.TypeString,
+		Description:  "Project membership type. One of: admin, developer, operator, read_only",
+		Required:     true,
+		Type:         schema.TypeString,
+		ValidateFunc: validation.StringInSlice(projectMemberTypes, false),
 	},
 	"accepted": {
 		Computed:    true,
 		Description: "Whether the user has accepted project membership or not",
 		Type:        schema.TypeBool,
 	},
+	"invite_time": {
+		Computed:    true,
+		Description: "Time the pending invitation was sent",
+		Type:        schema.TypeString,
+	},
 }
 
 func resourceProjectUser() *schema.Resource {
@@ -51,7 +60,7 @@ func resourceProjectUser() *schema.Resource {
 }
 
 func resourceProjectUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	projectName := d.Get("project").(string)
 	email := d.Get("email").(string)
 	err := client.ProjectUsers.Invite(
@@ -74,7 +83,7 @@ func resourceProjectUserCreate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceProjectUserRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, email := splitResourceID2(d.Id())
 	user, invitation, err := client.ProjectUsers.Get(projectName, email)
@@ -98,6 +107,9 @@ func resourceProjectUserRead(ctx context.Context, d *schema.ResourceData, m inte
 		if err := d.Set("accepted", true); err != nil {
 			return diag.FromErr(err)
 		}
+		if err := d.Set("invite_time", ""); err != nil {
+			return diag.FromErr(err)
+		}
 	} else {
 		if err := d.Set("member_type", invitation.MemberType); err != nil {
 			return diag.FromErr(err)
@@ -105,12 +117,15 @@ func resourceProjectUserRead(ctx context.Context, d *schema.ResourceData, m inte
 		if err := d.Set("accepted", false); err != nil {
 			return diag.FromErr(err)
 		}
+		if err := d.Set("invite_time", invitation.InviteTime); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 	return nil
 }
 
 func resourceProjectUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, email := splitResourceID2(d.Id())
 	memberType := d.Get("member_type").(string)
@@ -129,7 +144,7 @@ func resourceProjectUserUpdate(ctx context.Context, d *schema.ResourceData, m in
 }
 
 func resourceProjectUserDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, email := splitResourceID2(d.Id())
 	user, invitation, err := client.ProjectUsers.Get(projectName, email)
@@ -0,0 +1,391 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var aivenProjectUsersSchema = map[string]*schema.Schema{
+	"project": {
+		Description: "The project the users belong to",
+		ForceNew:    true,
+		Required:    true,
+		Type:        schema.TypeString,
+	},
+	"members": {
+		Description:  "Map of user email to project membership type, users missing from the map are removed from the project",
+		Required:     true,
+		Type:         schema.TypeMap,
+		Elem:         &schema.Schema{Type: schema.TypeString},
+		ValidateFunc: validateProjectMembers,
+	},
+	"users": {
+		Computed:    true,
+		Description: "Project members and pending invitations",
+		Type:        schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"email": {
+					Computed:    true,
+					Description: "Email address of the user",
+					Type:        schema.TypeString,
+				},
+				"member_type": {
+					Computed:    true,
+					Description: "Project membership type",
+					Type:        schema.TypeString,
+				},
+				"real_name": {
+					Computed:    true,
+					Description: "Real name of the user, empty until the invitation is accepted",
+					Type:        schema.TypeString,
+				},
+				"accepted": {
+					Computed:    true,
+					Description: "Whether the user has accepted project membership or not",
+					Type:        schema.TypeBool,
+				},
+				"invite_time": {
+					Computed:    true,
+					Description: "Time the pending invitation was sent",
+					Type:        schema.TypeString,
+				},
+			},
+		},
+	},
+}
+
+func resourceProjectUsers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProjectUsersCreate,
+		ReadContext:   resourceProjectUsersRead,
+		UpdateContext: resourceProjectUsersUpdate,
+		DeleteContext: resourceProjectUsersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: aivenProjectUsersSchema,
+	}
+}
+
+func validateProjectMembers(v interface{}, k string) (ws []string, errors []error) {
+	for email, memberType := range v.(map[string]interface{}) {
+		valid := false
+		for _, t := range projectMemberTypes {
+			if memberType == t {
+				valid = true
+			}
+		}
+
+		if !valid {
+			errors = append(errors, fmt.Errorf("%q: invalid member type `%v` for %s, expected one of %v",
+				k, memberType, email, projectMemberTypes))
+		}
+	}
+
+	return
+}
+
+// projectUsersChanges lists the actions needed to turn the current project users and
+// pending invitations into the desired membership
+type projectUsersChanges struct {
+	invite            []aiven.CreateProjectInvitationRequest
+	updateUsers       map[string]aiven.UpdateProjectUserOrInvitationRequest
+	updateInvitations map[string]aiven.UpdateProjectUserOrInvitationRequest
+	deleteUsers       []string
+	deleteInvitations []string
+}
+
+// currentProjectMembers maps the emails of project users and pending invitations to their
+// membership type; users that are project members through an account team are managed by
+// the team and left out
+func currentProjectMembers(users []*aiven.ProjectUser, invitations []*aiven.ProjectInvitation) (map[string]string, map[string]bool) {
+	current := make(map[string]string)
+	isUser := make(map[string]bool)
+	for _, u := range users {
+		if u.TeamId != "" {
+			continue
+		}
+
+		current[u.Email] = u.MemberType
+		isUser[u.Email] = true
+	}
+	for _, i := range invitations {
+		if !isUser[i.UserEmail] {
+			current[i.UserEmail] = i.MemberType
+		}
+	}
+
+	return current, isUser
+}
+
+// diffProjectUsers compares the desired membership with the current one
+func diffProjectUsers(
+	members map[string]string,
+	users []*aiven.ProjectUser,
+	invitations []*aiven.ProjectInvitation,
+) projectUsersChanges {
+	current, isUser := currentProjectMembers(users, invitations)
+
+	// expired invitations are removed by Aiven, such users are invited again
+	diff := diffStringMaps(current, members)
+
+	changes := projectUsersChanges{
+		updateUsers:       make(map[string]aiven.UpdateProjectUserOrInvitationRequest),
+		updateInvitations: make(map[string]aiven.UpdateProjectUserOrInvitationRequest),
+	}
+	for _, email := range diff.create {
+		changes.invite = append(changes.invite,
+			aiven.CreateProjectInvitationRequest{UserEmail: email, MemberType: members[email]})
+	}
+	for _, email := range diff.update {
+		r := aiven.UpdateProjectUserOrInvitationRequest{MemberType: members[email]}
+		if isUser[email] {
+			changes.updateUsers[email] = r
+		} else {
+			changes.updateInvitations[email] = r
+		}
+	}
+	for _, email := range diff.delete {
+		if isUser[email] {
+			changes.deleteUsers = append(changes.deleteUsers, email)
+		} else {
+			changes.deleteInvitations = append(changes.deleteInvitations, email)
+		}
+	}
+
+	return changes
+}
+
+// checkProjectUsersChanges refuses changes that remove the user of the API token from the
+// project or that leave the project without an admin
+func checkProjectUsersChanges(changes projectUsersChanges, users []*aiven.ProjectUser, caller string) error {
+	removed := make(map[string]bool)
+	for _, email := range changes.deleteUsers {
+		if email == caller {
+			return fmt.Errorf("cannot remove %s from the project, it is the user of the API token", email)
+		}
+		removed[email] = true
+	}
+
+	admins, remaining := 0, 0
+	for _, u := range users {
+		if u.MemberType == "admin" {
+			admins++
+		}
+
+		memberType := u.MemberType
+		if u.TeamId == "" {
+			if removed[u.Email] {
+				continue
+			}
+			if r, ok := changes.updateUsers[u.Email]; ok {
+				memberType = r.MemberType
+			}
+		}
+		if memberType == "admin" {
+			remaining++
+		}
+	}
+
+	if admins > 0 && remaining == 0 {
+		return fmt.Errorf("cannot remove the last admin of the project")
+	}
+
+	return nil
+}
+
+func resourceProjectUsersCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	projectName := d.Get("project").(string)
+
+	if err := syncProjectUsers(ctx, m.(*providerMeta).client, projectName, projectMembers(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(projectName)
+
+	return resourceProjectUsersRead(ctx, d, m)
+}
+
+func resourceProjectUsersUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if err := syncProjectUsers(ctx, m.(*providerMeta).client, d.Id(), projectMembers(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceProjectUsersRead(ctx, d, m)
+}
+
+func projectMembers(d *schema.ResourceData) map[string]string {
+	members := make(map[string]string)
+	for email, memberType := range d.Get("members").(map[string]interface{}) {
+		members[email] = memberType.(string)
+	}
+
+	return members
+}
+
+// syncProjectUsers invites missing users, updates membership types and removes project
+// users and invitations that are not in the members map
+func syncProjectUsers(ctx context.Context, client *aiven.Client, projectName string, members map[string]string) error {
+	users, invitations, err := client.ProjectUsers.List(projectName)
+	if err != nil {
+		return err
+	}
+
+	return applyProjectUsersChanges(ctx, client, projectName, users, diffProjectUsers(members, users, invitations))
+}
+
+// applyProjectUsersChanges sends invitations and membership type updates before removing
+// anybody, so that a failed apply does not leave the project with fewer admins than planned
+func applyProjectUsersChanges(
+	ctx context.Context,
+	client *aiven.Client,
+	projectName string,
+	users []*aiven.ProjectUser,
+	changes projectUsersChanges,
+) error {
+	if len(changes.deleteUsers) > 0 || len(changes.updateUsers) > 0 {
+		caller, err := aivenAPICaller(ctx, client)
+		if err != nil {
+			return err
+		}
+
+		if err := checkProjectUsersChanges(changes, users, caller); err != nil {
+			return err
+		}
+	}
+
+	for _, u := range changes.invite {
+		log.Printf("[DEBUG] inviting %s to project %s as %s", u.UserEmail, projectName, u.MemberType)
+		if err := client.ProjectUsers.Invite(projectName, u); err != nil {
+			return fmt.Errorf("cannot invite %s to project: %w", u.UserEmail, err)
+		}
+	}
+
+	for email, r := range changes.updateUsers {
+		log.Printf("[DEBUG] changing project %s user %s membership type to %s", projectName, email, r.MemberType)
+		if err := client.ProjectUsers.UpdateUser(projectName, email, r); err != nil {
+			return fmt.Errorf("cannot update project user %s: %w", email, err)
+		}
+	}
+
+	for email, r := range changes.updateInvitations {
+		log.Printf("[DEBUG] changing project %s invitation of %s to %s", projectName, email, r.MemberType)
+		if err := client.ProjectUsers.UpdateInvitation(projectName, email, r); err != nil {
+			return fmt.Errorf("cannot update project invitation of %s: %w", email, err)
+		}
+	}
+
+	for _, email := range changes.deleteUsers {
+		log.Printf("[DEBUG] removing project %s user %s", projectName, email)
+		if err := client.ProjectUsers.DeleteUser(projectName, email); err != nil && !aiven.IsNotFound(err) {
+			return fmt.Errorf("cannot remove project user %s: %w", email, err)
+		}
+	}
+
+	for _, email := range changes.deleteInvitations {
+		log.Printf("[DEBUG] deleting project %s invitation of %s", projectName, email)
+		if err := client.ProjectUsers.DeleteInvitation(projectName, email); err != nil && !aiven.IsNotFound(err) {
+			return fmt.Errorf("cannot delete project invitation of %s: %w", email, err)
+		}
+	}
+
+	return nil
+}
+
+func resourceProjectUsersRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	projectName := d.Id()
+
+	users, invitations, err := client.ProjectUsers.List(projectName)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	members := make(map[string]string)
+	var list []map[string]interface{}
+	for _, u := range users {
+		if u.TeamId != "" {
+			continue
+		}
+
+		members[u.Email] = u.MemberType
+		list = append(list, map[string]interface{}{
+			"email":       u.Email,
+			"member_type": u.MemberType,
+			"real_name":   u.RealName,
+			"accepted":    true,
+			"invite_time": "",
+		})
+	}
+
+	for _, i := range invitations {
+		if _, ok := members[i.UserEmail]; ok {
+			continue
+		}
+
+		members[i.UserEmail] = i.MemberType
+		list = append(list, map[string]interface{}{
+			"email":       i.UserEmail,
+			"member_type": i.MemberType,
+			"real_name":   "",
+			"accepted":    false,
+			"invite_time": i.InviteTime,
+		})
+	}
+
+	if err := d.Set("project", projectName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("members", members); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("users", list); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceProjectUsersDelete removes the users and invitations of the members map from the
+// project, other members and the user of the API token are kept
+func resourceProjectUsersDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	projectName := d.Id()
+
+	users, invitations, err := client.ProjectUsers.List(projectName)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	caller, err := aivenAPICaller(ctx, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	members, _ := currentProjectMembers(users, invitations)
+	for email := range d.Get("members").(map[string]interface{}) {
+		if email == caller {
+			log.Printf("[WARN] keeping %s in project %s, it is the user of the API token", email, projectName)
+			continue
+		}
+		delete(members, email)
+	}
+
+	err = applyProjectUsersChanges(ctx, client, projectName, users, diffProjectUsers(members, users, invitations))
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package aiven
+
+import (
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+)
+
+func Test_checkProjectUsersChanges(t *testing.T) {
+	users := []*aiven.ProjectUser{
+		{Email: "caller@example.com", MemberType: "developer"},
+		{Email: "admin@example.com", MemberType: "admin"},
+		{Email: "team@example.com", MemberType: "admin", TeamId: "t1"},
+	}
+	noTeam := users[:2]
+
+	tests := []struct {
+		name    string
+		users   []*aiven.ProjectUser
+		changes projectUsersChanges
+		wantErr bool
+	}{
+		{
+			"caller is not removed",
+			users,
+			projectUsersChanges{deleteUsers: []string{"caller@example.com"}},
+			true,
+		},
+		{
+			"last admin is not removed",
+			noTeam,
+			projectUsersChanges{deleteUsers: []string{"admin@example.com"}},
+			true,
+		},
+		{
+			"last admin is not demoted",
+			noTeam,
+			projectUsersChanges{updateUsers: map[string]aiven.UpdateProjectUserOrInvitationRequest{
+				"admin@example.com": {MemberType: "read_only"},
+			}},
+			true,
+		},
+		{
+			"admin is removed after another one is promoted",
+			noTeam,
+			projectUsersChanges{
+				deleteUsers: []string{"admin@example.com"},
+				updateUsers: map[string]aiven.UpdateProjectUserOrInvitationRequest{
+					"caller@example.com": {MemberType: "admin"},
+				},
+			},
+			false,
+		},
+		{
+			"team admin remains",
+			users,
+			projectUsersChanges{deleteUsers: []string{"admin@example.com"}},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkProjectUsersChanges(tt.changes, tt.users, "caller@example.com")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkProjectUsersChanges() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -28,10 +28,11 @@ var aivenProjectVPCSchema = map[string]*schema.Schema{
 		Type:        schema.TypeString,
 	},
 	"network_cidr": {
-		Description: "Network address range used by the VPC like 192.168.0.0/24",
-		ForceNew:    true,
-		Required:    true,
-		Type:        schema.TypeString,
+		Description:  "Network address range used by the VPC like 192.168.0.0/24",
+		ForceNew:     true,
+		Required:     true,
+		Type:         schema.TypeString,
+		ValidateFunc: validateNetworkCIDR,
 	},
 	"state": {
 		Computed:    true,
@@ -48,6 +49,7 @@ func resourceProjectVPC() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceProjectVPCState,
 		},
+		CustomizeDiff: resourceProjectVPCCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(4 * time.Minute),
 			Delete: schema.DefaultTimeout(4 * time.Minute),
@@ -58,7 +60,7 @@ func resourceProjectVPC() *schema.Resource {
 }
 
 func resourceProjectVPCCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	projectName := d.Get("project").(string)
 	vpc, err := client.VPCs.Create(
 		projectName,
@@ -90,7 +92,7 @@ func resourceProjectVPCCreate(ctx context.Context, d *schema.ResourceData, m int
 }
 
 func resourceProjectVPCRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, vpcID := splitResourceID2(d.Id())
 	vpc, err := client.VPCs.Get(projectName, vpcID)
@@ -107,7 +109,7 @@ func resourceProjectVPCRead(_ context.Context, d *schema.ResourceData, m interfa
 }
 
 func resourceProjectVPCDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, vpcID := splitResourceID2(d.Id())
 
@@ -139,6 +141,55 @@ func resourceProjectVPCState(ctx context.Context, d *schema.ResourceData, m inte
 	return []*schema.ResourceData{d}, nil
 }
 
+// resourceProjectVPCCustomizeDiff detects network CIDRs that overlap with other VPCs
+// of the project or with networks peered with them
+func resourceProjectVPCCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("network_cidr") || !d.NewValueKnown("network_cidr") || !d.NewValueKnown("project") {
+		return nil
+	}
+
+	projectName := d.Get("project").(string)
+	vpcs, err := m.(*providerMeta).client.VPCs.List(projectName)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("cannot get a list of project VPCs: %w", err)
+	}
+
+	_, vpcID := splitResourceID2(d.Id())
+	var used []namedCIDR
+	for _, vpc := range vpcs {
+		if d.Id() != "" && vpc.ProjectVPCID == vpcID {
+			continue
+		}
+
+		used = append(used, projectVPCNetworks(vpc)...)
+	}
+
+	cidr, err := normalizeNetworkCIDR(d.Get("network_cidr").(string))
+	if err != nil {
+		return err
+	}
+
+	return findCIDROverlap(cidr, used)
+}
+
+// projectVPCNetworks lists the network of a project VPC and the networks of its peering connections
+func projectVPCNetworks(vpc *aiven.VPC) []namedCIDR {
+	networks := []namedCIDR{{cidr: vpc.NetworkCIDR, owner: fmt.Sprintf("project VPC %s in %s", vpc.ProjectVPCID, vpc.CloudName)}}
+	for _, pc := range vpc.PeeringConnections {
+		for _, c := range pc.UserPeerNetworkCIDRs {
+			networks = append(networks, namedCIDR{
+				cidr:  c,
+				owner: fmt.Sprintf("peering connection to %s/%s", pc.PeerCloudAccount, pc.PeerVPC),
+			})
+		}
+	}
+
+	return networks
+}
+
 func copyVPCPropertiesFromAPIResponseToTerraform(d *schema.ResourceData, vpc *aiven.VPC, project string) error {
 	if err := d.Set("project", project); err != nil {
 		return err
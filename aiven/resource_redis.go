@@ -9,6 +9,7 @@ import (
 
 func redisSchema() map[string]*schema.Schema {
 	s := serviceCommonSchema()
+	s["restore_from"] = serviceRestoreFromSchema()
 	s[ServiceTypeRedis] = &schema.Schema{
 		Type:        schema.TypeList,
 		MaxItems:    1,
@@ -43,6 +44,7 @@ func resourceRedis() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceState,
 		},
+		CustomizeDiff: resourceServiceCustomizeDiff(ServiceTypeRedis),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(20 * time.Minute),
 			Update: schema.DefaultTimeout(20 * time.Minute),
@@ -12,6 +12,7 @@ import (
 	"github.com/aiven/aiven-go-client"
 	"github.com/aiven/terraform-provider-aiven/aiven/templates"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -51,7 +52,7 @@ func availableServiceTypes() []string {
 }
 
 func serviceCommonSchema() map[string]*schema.Schema {
-	return map[string]*schema.Schema{
+	return tagsSchema(map[string]*schema.Schema{
 		"project": {
 			Type:        schema.TypeString,
 			Required:    true,
@@ -79,6 +80,11 @@ func serviceCommonSchema() map[string]*schema.Schema {
 			Computed:    true,
 			Description: "Aiven internal service type code",
 		},
+		"monthly_price_usd": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Monthly price of the plan in the cloud of the service in USD",
+		},
 		"project_vpc_id": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -203,7 +209,7 @@ func serviceCommonSchema() map[string]*schema.Schema {
 				},
 			},
 		},
-	}
+	}, "service")
 }
 
 var aivenServiceSchema = map[string]*schema.Schema{
@@ -712,6 +718,16 @@ func resourceService() *schema.Resource {
 	}
 }
 
+// resourceServiceCustomizeDiff combines the plan time checks of typed service resources
+func resourceServiceCustomizeDiff(serviceType string) schema.CustomizeDiffFunc {
+	funcs := []schema.CustomizeDiffFunc{resourceServiceVersionCustomizeDiff}
+	if serviceUserConfigSupports(serviceType, "service_to_fork_from") {
+		funcs = append(funcs, resourceServiceRestoreCustomizeDiff(serviceType))
+	}
+
+	return customdiff.Sequence(append(funcs, resourceServicePriceCustomizeDiff(serviceType), resourceTagsCustomizeDiff)...)
+}
+
 func resourceServiceCreateWrapper(serviceType string) schema.CreateContextFunc {
 	if serviceType == "service" {
 		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -769,9 +785,9 @@ func resourceServiceCreateWrapper(serviceType string) schema.CreateContextFunc {
 }
 
 func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	serviceType := d.Get("service_type").(string)
-	userConfig := ConvertTerraformUserConfigToAPICompatibleFormat("service", serviceType, true, d)
+	userConfig := serviceRestoreUserConfig(d, ConvertTerraformUserConfigToAPICompatibleFormat("service", serviceType, true, d))
 	vpcID := d.Get("project_vpc_id").(string)
 	var apiServiceIntegrations []aiven.NewServiceIntegration
 	tfServiceIntegrations := d.Get("service_integrations")
@@ -795,7 +811,12 @@ func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interf
 		vpcIDPointer = &vpcID
 	}
 
-	_, err := client.Services.Create(
+	restoreBackup, err := resolveServiceRestoreBackup(ctx, d, client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.Services.Create(
 		project,
 		aiven.CreateServiceRequest{
 			Cloud:                 d.Get("cloud_name").(string),
@@ -821,16 +842,30 @@ func resourceServiceCreate(ctx context.Context, d *schema.ResourceData, m interf
 
 	d.SetId(buildResourceID(d.Get("project").(string), service.Name))
 
+	if serviceHasTags(d) && len(resourceTags(d.Get("tag").(*schema.Set), m.(*providerMeta).defaultTags)) != 0 {
+		if err := updateResourceTags(ctx, d, m, serviceTagsPath(project, service.Name)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := setServiceRestoreLineage(d, restoreBackup); err != nil {
+		return diag.FromErr(err)
+	}
+
 	err = copyServicePropertiesFromAPIResponseToTerraform(d, service, d.Get("project").(string))
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := setServiceMonthlyPrice(ctx, d, m, service); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
 
-func resourceServiceRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+func resourceServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
 
 	projectName, serviceName := splitResourceID2(d.Id())
 	service, err := client.Services.Get(projectName, serviceName)
@@ -843,11 +878,21 @@ func resourceServiceRead(_ context.Context, d *schema.ResourceData, m interface{
 		return diag.FromErr(err)
 	}
 
+	if err := setServiceMonthlyPrice(ctx, d, m, service); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if serviceHasTags(d) {
+		if err := readResourceTags(ctx, d, m, serviceTagsPath(projectName, serviceName)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return nil
 }
 
 func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if d.HasChanges("service_integrations") && len(d.Get("service_integrations").([]interface{})) != 0 {
 		return diag.Errorf("service_integrations field can only be set during creation of a service")
@@ -855,6 +900,11 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interf
 
 	projectName, serviceName := splitResourceID2(d.Id())
 	userConfig := ConvertTerraformUserConfigToAPICompatibleFormat("service", d.Get("service_type").(string), false, d)
+	diags := resourceServiceUpgradeCheck(ctx, d, client, userConfig)
+	if diags.HasError() {
+		return diags
+	}
+
 	vpcID := d.Get("project_vpc_id").(string)
 	var vpcIDPointer *string
 	if len(vpcID) > 0 {
@@ -883,16 +933,26 @@ func resourceServiceUpdate(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.FromErr(err)
 	}
 
+	if serviceHasTags(d) && (d.HasChange("tag") || d.HasChange("tags_all")) {
+		if err := updateResourceTags(ctx, d, m, serviceTagsPath(projectName, serviceName)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	err = copyServicePropertiesFromAPIResponseToTerraform(d, service, projectName)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	return nil
+	if err := setServiceMonthlyPrice(ctx, d, m, service); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
 }
 
 func resourceServiceDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName := splitResourceID2(d.Id())
 
@@ -904,8 +964,8 @@ func resourceServiceDelete(_ context.Context, d *schema.ResourceData, m interfac
 	return nil
 }
 
-func resourceServiceState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+func resourceServiceState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 2 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>", d.Id())
@@ -922,6 +982,10 @@ func resourceServiceState(_ context.Context, d *schema.ResourceData, m interface
 		return nil, err
 	}
 
+	if err := setServiceMonthlyPrice(ctx, d, m, service); err != nil {
+		return nil, err
+	}
+
 	return []*schema.ResourceData{d}, nil
 }
 
@@ -934,7 +998,7 @@ func resourceServiceWait(ctx context.Context, d *schema.ResourceData, m interfac
 	}
 
 	w := &ServiceChangeWaiter{
-		Client:      m.(*aiven.Client),
+		Client:      m.(*providerMeta).client,
 		Operation:   operation,
 		Project:     d.Get("project").(string),
 		ServiceName: d.Get("service_name").(string),
@@ -262,6 +262,7 @@ func resourceServiceIntegration() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceIntegrationState,
 		},
+		CustomizeDiff: resourceServiceIntegrationCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(2 * time.Minute),
 		},
@@ -280,7 +281,7 @@ func plainEndpointID(fullEndpointID *string) *string {
 
 func resourceServiceIntegrationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var integration *aiven.ServiceIntegration
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	projectName := d.Get("project").(string)
 	integrationType := d.Get("integration_type").(string)
 	sourceServiceName := d.Get("source_service_name").(string)
@@ -366,7 +367,7 @@ func resourceServiceIntegrationCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceServiceIntegrationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, integrationID := splitResourceID2(d.Id())
 	integration, err := client.ServiceIntegrations.Get(projectName, integrationID)
@@ -387,7 +388,7 @@ func resourceServiceIntegrationRead(_ context.Context, d *schema.ResourceData, m
 }
 
 func resourceServiceIntegrationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, integrationID := splitResourceID2(d.Id())
 	integrationType := d.Get("integration_type").(string)
@@ -408,7 +409,7 @@ func resourceServiceIntegrationUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceServiceIntegrationDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, integrationID := splitResourceID2(d.Id())
 	err := client.ServiceIntegrations.Delete(projectName, integrationID)
@@ -420,7 +421,7 @@ func resourceServiceIntegrationDelete(_ context.Context, d *schema.ResourceData,
 }
 
 func resourceServiceIntegrationState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 2 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<integration_id>", d.Id())
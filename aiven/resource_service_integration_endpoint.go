@@ -34,6 +34,7 @@ var aivenServiceIntegrationEndpointSchema = map[string]*schema.Schema{
 	"endpoint_config": {
 		Description: "Integration endpoint specific backend configuration",
 		Computed:    true,
+		Sensitive:   true,
 		Type:        schema.TypeMap,
 		Elem:        &schema.Schema{Type: schema.TypeString},
 	},
@@ -164,7 +165,7 @@ func resourceServiceIntegrationEndpoint() *schema.Resource {
 }
 
 func resourceServiceIntegrationEndpointCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	projectName := d.Get("project").(string)
 	endpointType := d.Get("endpoint_type").(string)
 	userConfig := ConvertTerraformUserConfigToAPICompatibleFormat("endpoint", endpointType, true, d)
@@ -187,7 +188,7 @@ func resourceServiceIntegrationEndpointCreate(ctx context.Context, d *schema.Res
 }
 
 func resourceServiceIntegrationEndpointRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, endpointID := splitResourceID2(d.Id())
 	endpoint, err := client.ServiceIntegrationEndpoints.Get(projectName, endpointID)
@@ -204,7 +205,7 @@ func resourceServiceIntegrationEndpointRead(_ context.Context, d *schema.Resourc
 }
 
 func resourceServiceIntegrationEndpointUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, endpointID := splitResourceID2(d.Id())
 	endpointType := d.Get("endpoint_type").(string)
@@ -224,7 +225,7 @@ func resourceServiceIntegrationEndpointUpdate(ctx context.Context, d *schema.Res
 }
 
 func resourceServiceIntegrationEndpointDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, endpointID := splitResourceID2(d.Id())
 	err := client.ServiceIntegrationEndpoints.Delete(projectName, endpointID)
@@ -236,7 +237,7 @@ func resourceServiceIntegrationEndpointDelete(_ context.Context, d *schema.Resou
 }
 
 func resourceServiceIntegrationEndpointState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 2 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<endpoint_id>", d.Id())
@@ -261,14 +262,32 @@ func copyServiceIntegrationEndpointPropertiesFromAPIResponseToTerraform(
 	endpoint *aiven.ServiceIntegrationEndpoint,
 	project string,
 ) error {
-	d.Set("project", project)
-	d.Set("endpoint_name", endpoint.EndpointName)
+	if err := d.Set("project", project); err != nil {
+		return err
+	}
+	if err := d.Set("endpoint_name", endpoint.EndpointName); err != nil {
+		return err
+	}
 	endpointType := endpoint.EndpointType
-	d.Set("endpoint_type", endpointType)
-	userConfig := ConvertAPIUserConfigToTerraformCompatibleFormat("endpoint", endpointType, endpoint.UserConfig)
-	if len(userConfig) > 0 {
-		d.Set(endpointType+"_user_config", userConfig)
+	if err := d.Set("endpoint_type", endpointType); err != nil {
+		return err
+	}
+
+	// The user config is always set, even if it is empty, to detect changes made
+	// outside of Terraform
+	userConfigKey := endpointType + "_user_config"
+	if _, ok := aivenServiceIntegrationEndpointSchema[userConfigKey]; ok {
+		userConfig := ConvertAPIUserConfigToTerraformCompatibleFormat("endpoint", endpointType, endpoint.UserConfig)
+		if current, ok := d.Get(userConfigKey).([]interface{}); ok && len(current) > 0 && len(userConfig) > 0 {
+			if c, ok := current[0].(map[string]interface{}); ok {
+				preserveMaskedUserConfigValues(userConfig[0], c)
+			}
+		}
+		if err := d.Set(userConfigKey, userConfig); err != nil {
+			return err
+		}
 	}
+
 	// Must coerse all values into strings
 	endpointConfig := map[string]string{}
 	if len(endpoint.EndpointConfig) > 0 {
@@ -276,7 +295,29 @@ func copyServiceIntegrationEndpointPropertiesFromAPIResponseToTerraform(
 			endpointConfig[key] = fmt.Sprintf("%v", value)
 		}
 	}
-	d.Set("endpoint_config", endpointConfig)
+	if err := d.Set("endpoint_config", endpointConfig); err != nil {
+		return err
+	}
 
 	return nil
 }
+
+// preserveMaskedUserConfigValues keeps sensitive values from the state when the API does
+// not return them or returns them masked, otherwise they would show up as a change in
+// every plan
+func preserveMaskedUserConfigValues(userConfig, current map[string]interface{}) {
+	for k, v := range userConfig {
+		if !isSensitiveUserConfigKey(decodeKeyName(k)) {
+			continue
+		}
+
+		s, ok := v.(string)
+		if !ok || (s != "" && strings.Trim(s, "*") != "") {
+			continue
+		}
+
+		if c, ok := current[k].(string); ok && c != "" {
+			userConfig[k] = c
+		}
+	}
+}
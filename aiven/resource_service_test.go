@@ -156,7 +156,7 @@ func testAccCheckAivenServiceCommonAttributes(n string) resource.TestCheckFunc {
 }
 
 func testAccCheckAivenServiceResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each aiven_service is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -5,7 +5,10 @@ package aiven
 import (
 	"context"
 	"fmt"
+	"path"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -36,39 +39,40 @@ var aivenServiceUserSchema = map[string]*schema.Schema{
 		Type:         schema.TypeList,
 		Optional:     true,
 		Description:  "Command category rules",
-		ForceNew:     true,
 		RequiredWith: []string{"redis_acl_commands", "redis_acl_keys"},
 		Elem: &schema.Schema{
-			Type: schema.TypeString,
+			Type:         schema.TypeString,
+			ValidateFunc: validateRedisACLCategory,
 		},
 	},
 	"redis_acl_commands": {
 		Type:         schema.TypeList,
 		Optional:     true,
 		Description:  "Rules for individual commands",
-		ForceNew:     true,
 		RequiredWith: []string{"redis_acl_categories", "redis_acl_keys"},
 		Elem: &schema.Schema{
-			Type: schema.TypeString,
+			Type:         schema.TypeString,
+			ValidateFunc: validateRedisACLCommand,
 		},
 	},
 	"redis_acl_keys": {
 		Type:         schema.TypeList,
 		Optional:     true,
 		Description:  "Key access rules",
-		ForceNew:     true,
 		RequiredWith: []string{"redis_acl_categories", "redis_acl_commands"},
 		Elem: &schema.Schema{
-			Type: schema.TypeString,
+			Type:         schema.TypeString,
+			ValidateFunc: validateRedisACLPattern,
 		},
 	},
 	"redis_acl_channels": {
 		Type:        schema.TypeList,
 		Optional:    true,
-		Description: "Permitted pub/sub channel patterns",
-		ForceNew:    true,
+		Computed:    true,
+		Description: "Permitted pub/sub channel patterns, when not set on creation the redis_acl_channels_default of the service applies",
 		Elem: &schema.Schema{
-			Type: schema.TypeString,
+			Type:         schema.TypeString,
+			ValidateFunc: validateRedisACLPattern,
 		},
 	},
 	"password": {
@@ -79,6 +83,24 @@ var aivenServiceUserSchema = map[string]*schema.Schema{
 		Description:      "Password of the user",
 		DiffSuppressFunc: emptyObjectDiffSuppressFunc,
 	},
+	"rotation_trigger": {
+		Type:          schema.TypeString,
+		Optional:      true,
+		Description:   "Arbitrary value, changing it regenerates the credentials of the user",
+		ConflictsWith: []string{"password"},
+	},
+	"rotate_after": {
+		Type:          schema.TypeString,
+		Optional:      true,
+		Description:   "Duration like 720h after which the credentials of the user are regenerated on the next apply",
+		ValidateFunc:  validateDurationString,
+		ConflictsWith: []string{"password"},
+	},
+	"password_rotation_time": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Time the credentials were last generated by Terraform",
+	},
 	"authentication": {
 		Type:             schema.TypeString,
 		Optional:         true,
@@ -103,6 +125,11 @@ var aivenServiceUserSchema = map[string]*schema.Schema{
 		Computed:    true,
 		Description: "Access certificate key for the user if applicable for the service in question",
 	},
+	"access_cert_not_valid_after_time": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Expiration time of the access certificate if applicable for the service in question",
+	},
 }
 
 func resourceServiceUser() *schema.Resource {
@@ -114,13 +141,14 @@ func resourceServiceUser() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceServiceUserState,
 		},
+		CustomizeDiff: resourceServiceUserCustomizeDiff,
 
 		Schema: aivenServiceUserSchema,
 	}
 }
 
 func resourceServiceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName := d.Get("project").(string)
 	serviceName := d.Get("service_name").(string)
@@ -154,27 +182,157 @@ func resourceServiceUserCreate(ctx context.Context, d *schema.ResourceData, m in
 	}
 
 	d.SetId(buildResourceID(projectName, serviceName, username))
+	if err := d.Set("password_rotation_time", time.Now().UTC().Format(time.RFC3339)); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceServiceUserRead(ctx, d, m)
 }
 
 func resourceServiceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, username := splitResourceID3(d.Id())
 
-	_, err := client.ServiceUsers.Update(projectName, serviceName, username,
-		aiven.ModifyServiceUserRequest{
+	// the API generates new credentials when no password is sent; otherwise the current
+	// password is sent along so changing only the authentication plugin keeps it
+	rotate := serviceUserRotationPlanned(d)
+	if rotate || d.HasChanges("password", "authentication") {
+		req := aiven.ModifyServiceUserRequest{
 			Authentication: optionalStringPointer(d, "authentication"),
-			NewPassword:    optionalStringPointer(d, "password"),
-		})
-	if err != nil {
-		return diag.FromErr(err)
+		}
+		if !rotate {
+			req.NewPassword = optionalStringPointer(d, "password")
+		}
+
+		if _, err := client.ServiceUsers.Update(projectName, serviceName, username, req); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChanges("redis_acl_categories", "redis_acl_commands", "redis_acl_keys", "redis_acl_channels") {
+		operation := aiven.UpdateOperationSetAccessControl
+		_, err := client.ServiceUsers.Update(projectName, serviceName, username,
+			aiven.ModifyServiceUserRequest{
+				Operation: &operation,
+				AccessControl: &aiven.AccessControl{
+					RedisACLCategories: flattenToString(d.Get("redis_acl_categories").([]interface{})),
+					RedisACLCommands:   flattenToString(d.Get("redis_acl_commands").([]interface{})),
+					RedisACLKeys:       flattenToString(d.Get("redis_acl_keys").([]interface{})),
+					RedisACLChannels:   flattenToString(d.Get("redis_acl_channels").([]interface{})),
+				},
+			})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if rotate || serviceUserRotationStart(d.Get("password_rotation_time").(string), d.Get("rotate_after").(string)) {
+		if err := d.Set("password_rotation_time", time.Now().UTC().Format(time.RFC3339)); err != nil {
+			return diag.FromErr(err)
+		}
 	}
 
 	return resourceServiceUserRead(ctx, d, m)
 }
 
+// resourceServiceUserCustomizeDiff plans a credentials rotation when rotation_trigger changes
+// or when the credentials are older than rotate_after
+func resourceServiceUserCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	lastRotation := d.Get("password_rotation_time").(string)
+	rotateAfter := d.Get("rotate_after").(string)
+	if !d.HasChange("rotation_trigger") && !serviceUserRotationDue(lastRotation, rotateAfter, time.Now()) {
+		if serviceUserRotationStart(lastRotation, rotateAfter) {
+			// the age of credentials created before rotation support is unknown, start
+			// counting when the plan is applied
+			return d.SetNewComputed("password_rotation_time")
+		}
+
+		return nil
+	}
+
+	for _, k := range []string{"password", "password_rotation_time", "access_cert", "access_key", "access_cert_not_valid_after_time"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// serviceUserRotationPlanned checks if the plan rotates the credentials, a rotation replaces
+// the recorded password_rotation_time while starting to count the age of credentials of
+// older users only sets it
+func serviceUserRotationPlanned(d *schema.ResourceData) bool {
+	return d.HasChange("rotation_trigger") || d.HasChange("password_rotation_time")
+}
+
+// serviceUserRotationStart checks if rotate_after is set for credentials of unknown age
+func serviceUserRotationStart(lastRotation, rotateAfter string) bool {
+	return lastRotation == "" && rotateAfter != ""
+}
+
+// serviceUserRotationDue checks if credentials generated at lastRotation are older than rotateAfter
+func serviceUserRotationDue(lastRotation, rotateAfter string, now time.Time) bool {
+	if lastRotation == "" || rotateAfter == "" {
+		return false
+	}
+
+	t, err := time.Parse(time.RFC3339, lastRotation)
+	if err != nil {
+		return false
+	}
+
+	after, err := time.ParseDuration(rotateAfter)
+	if err != nil {
+		return false
+	}
+
+	return !now.Before(t.Add(after))
+}
+
+var (
+	redisACLCategoryRegexp = regexp.MustCompile(`^[+-]@[a-z]+$`)
+	redisACLCommandRegexp  = regexp.MustCompile(`(?i)^[+-][a-z0-9_]+(\|[a-z0-9_-]+)?$`)
+)
+
+// validateRedisACLCategory checks a command category rule like +@read or -@dangerous
+func validateRedisACLCategory(v interface{}, k string) (ws []string, errors []error) {
+	if !redisACLCategoryRegexp.MatchString(v.(string)) {
+		errors = append(errors, fmt.Errorf("%q: invalid category rule `%s`, expected +@<category> or -@<category>", k, v))
+	}
+
+	return
+}
+
+// validateRedisACLCommand checks a command rule like +get, -flushall or +config|get
+func validateRedisACLCommand(v interface{}, k string) (ws []string, errors []error) {
+	if !redisACLCommandRegexp.MatchString(v.(string)) {
+		errors = append(errors, fmt.Errorf("%q: invalid command rule `%s`, expected +<command> or -<command>", k, v))
+	}
+
+	return
+}
+
+// validateRedisACLPattern checks a key or channel glob pattern like app:* or news.[ab]*
+func validateRedisACLPattern(v interface{}, k string) (ws []string, errors []error) {
+	pattern := v.(string)
+	if pattern == "" || strings.ContainsAny(pattern, " \t\r\n") {
+		errors = append(errors, fmt.Errorf("%q: pattern `%s` must be non-empty and contain no whitespace", k, pattern))
+		return
+	}
+
+	if _, err := path.Match(pattern, ""); err != nil {
+		errors = append(errors, fmt.Errorf("%q: invalid pattern `%s`: %s", k, pattern, err))
+	}
+
+	return
+}
+
 func copyServiceUserPropertiesFromAPIResponseToTerraform(
 	d *schema.ResourceData,
 	user *aiven.ServiceUser,
@@ -202,6 +360,9 @@ func copyServiceUserPropertiesFromAPIResponseToTerraform(
 	if err := d.Set("access_key", user.AccessKey); err != nil {
 		return err
 	}
+	if err := d.Set("access_cert_not_valid_after_time", user.AccessCertNotValidAfterTime); err != nil {
+		return err
+	}
 	if err := d.Set("redis_acl_keys", user.AccessControl.RedisACLKeys); err != nil {
 		return err
 	}
@@ -219,7 +380,7 @@ func copyServiceUserPropertiesFromAPIResponseToTerraform(
 }
 
 func resourceServiceUserRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, username := splitResourceID3(d.Id())
 	user, err := client.ServiceUsers.Get(projectName, serviceName, username)
@@ -236,7 +397,7 @@ func resourceServiceUserRead(_ context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceServiceUserDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, serviceName, username := splitResourceID3(d.Id())
 	err := client.ServiceUsers.Delete(projectName, serviceName, username)
@@ -248,7 +409,7 @@ func resourceServiceUserDelete(_ context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceServiceUserState(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	if len(strings.Split(d.Id(), "/")) != 3 {
 		return nil, fmt.Errorf("invalid identifier %v, expected <project_name>/<service_name>/<username>", d.Id())
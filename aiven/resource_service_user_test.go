@@ -5,10 +5,12 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
@@ -46,7 +48,7 @@ func TestAccAivenServiceUser_basic(t *testing.T) {
 }
 
 func testAccCheckAivenServiceUserResourceDestroy(s *terraform.State) error {
-	c := testAccProvider.Meta().(*aiven.Client)
+	c := testAccProvider.Meta().(*providerMeta).client
 
 	// loop through the resources in state, verifying each aiven_service is destroyed
 	for _, rs := range s.RootModule().Resources {
@@ -170,3 +172,61 @@ func testAccCheckAivenServiceUserAttributes(n string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func Test_serviceUserRotationDue(t *testing.T) {
+	now := time.Date(2021, 8, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		lastRotation string
+		rotateAfter  string
+		want         bool
+	}{
+		{"no rotation configured", "2021-07-01T12:00:00Z", "", false},
+		{"unknown last rotation", "", "720h", false},
+		{"not yet due", "2021-07-15T12:00:00Z", "720h", false},
+		{"exactly due", "2021-07-02T12:00:00Z", "720h", true},
+		{"overdue", "2021-06-01T12:00:00Z", "720h", true},
+		{"invalid time", "yesterday", "720h", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := serviceUserRotationDue(tt.lastRotation, tt.rotateAfter, now); got != tt.want {
+				t.Errorf("serviceUserRotationDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_validateRedisACLRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate schema.SchemaValidateFunc
+		value    string
+		wantErr  bool
+	}{
+		{"category allow", validateRedisACLCategory, "+@read", false},
+		{"category deny", validateRedisACLCategory, "-@dangerous", false},
+		{"category without prefix", validateRedisACLCategory, "@read", true},
+		{"category without @", validateRedisACLCategory, "+read", true},
+		{"command", validateRedisACLCommand, "+get", false},
+		{"subcommand", validateRedisACLCommand, "+config|get", false},
+		{"command with digits", validateRedisACLCommand, "+zrangebyscore2", false},
+		{"upper case command", validateRedisACLCommand, "-FLUSHALL", false},
+		{"upper case subcommand", validateRedisACLCommand, "+CLIENT|NO-EVICT", false},
+		{"command with whitespace", validateRedisACLCommand, "+config get", true},
+		{"command without prefix", validateRedisACLCommand, "get", true},
+		{"key pattern", validateRedisACLPattern, "app:*", false},
+		{"key character class", validateRedisACLPattern, "news.[ab]*", false},
+		{"unterminated character class", validateRedisACLPattern, "news.[ab", true},
+		{"whitespace", validateRedisACLPattern, "app *", true},
+		{"empty pattern", validateRedisACLPattern, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := tt.validate(tt.value, "rule")
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validate(%s) errors = %v, wantErr %v", tt.value, errs, tt.wantErr)
+			}
+		})
+	}
+}
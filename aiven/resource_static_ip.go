@@ -0,0 +1,344 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// staticIP is a static IP address of a project, aiven-go-client v1.6.1 has no static
+// IP endpoints so they are called through aivenAPIRequest
+type staticIP struct {
+	CloudName         string `json:"cloud_name"`
+	IPAddress         string `json:"ip_address"`
+	ServiceName       string `json:"service_name"`
+	State             string `json:"state"`
+	StaticIPAddressID string `json:"static_ip_address_id"`
+}
+
+type staticIPListResponse struct {
+	aiven.APIResponse
+	StaticIPs []*staticIP `json:"static_ips"`
+}
+
+type staticIPCreateRequest struct {
+	CloudName string `json:"cloud_name"`
+}
+
+type staticIPAssociateRequest struct {
+	ServiceName string `json:"service_name"`
+}
+
+func listStaticIPs(ctx context.Context, client *aiven.Client, project string) ([]*staticIP, error) {
+	var r staticIPListResponse
+	if err := aivenAPIRequest(ctx, client, http.MethodGet, aivenAPIPath("project", project, "static-ips"), nil, &r); err != nil {
+		return nil, err
+	}
+
+	return r.StaticIPs, nil
+}
+
+// getStaticIP looks the static IP up from the list of the project, the API has no
+// endpoint for a single address; a missing address is returned as a 404 aiven.Error
+func getStaticIP(ctx context.Context, client *aiven.Client, project, id string) (*staticIP, error) {
+	ips, err := listStaticIPs(ctx, client, project)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ip := range ips {
+		if ip.StaticIPAddressID == id && ip.State != "deleted" {
+			return ip, nil
+		}
+	}
+
+	return nil, aiven.Error{Status: 404, Message: fmt.Sprintf("static IP %s not found", id)}
+}
+
+var aivenStaticIPSchema = map[string]*schema.Schema{
+	"project": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Project name",
+	},
+	"cloud_name": {
+		Type:        schema.TypeString,
+		Required:    true,
+		ForceNew:    true,
+		Description: "Cloud the static IP address is allocated in",
+	},
+	"service_name": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "Service the static IP address is associated with",
+	},
+	"static_ip_address_id": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Static IP address ID",
+	},
+	"ip_address": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Static IP address",
+	},
+	"state": {
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "State of the static IP address",
+	},
+}
+
+func resourceStaticIP() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceStaticIPCreate,
+		ReadContext:   resourceStaticIPRead,
+		UpdateContext: resourceStaticIPUpdate,
+		DeleteContext: resourceStaticIPDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceStaticIPState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: aivenStaticIPSchema,
+	}
+}
+
+func resourceStaticIPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	project := d.Get("project").(string)
+
+	var ip staticIP
+	err := aivenAPIRequest(ctx, client, http.MethodPost, aivenAPIPath("project", project, "static-ips"),
+		staticIPCreateRequest{CloudName: d.Get("cloud_name").(string)}, &ip)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(buildResourceID(project, ip.StaticIPAddressID))
+
+	w := &StaticIPCreatedWaiter{
+		Context: ctx,
+		Client:  client,
+		Project: project,
+		ID:      ip.StaticIPAddressID,
+	}
+
+	if _, err := w.Conf(d.Timeout(schema.TimeoutCreate)).WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error waiting for static IP to be created: %s", err)
+	}
+
+	if serviceName := d.Get("service_name").(string); serviceName != "" {
+		if err := associateStaticIP(ctx, client, project, ip.StaticIPAddressID, serviceName); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceStaticIPRead(ctx, d, m)
+}
+
+func resourceStaticIPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	project, id := splitResourceID2(d.Id())
+	ip, err := getStaticIP(ctx, client, project, id)
+	if err != nil {
+		return diag.FromErr(resourceReadHandleNotFound(err, d))
+	}
+
+	if err := d.Set("project", project); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("cloud_name", ip.CloudName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("service_name", ip.ServiceName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("static_ip_address_id", ip.StaticIPAddressID); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ip_address", ip.IPAddress); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("state", ip.State); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceStaticIPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	project, id := splitResourceID2(d.Id())
+
+	if d.HasChange("service_name") {
+		o, n := d.GetChange("service_name")
+		if o.(string) != "" {
+			if err := dissociateStaticIP(ctx, client, project, id); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		if n.(string) != "" {
+			if err := associateStaticIP(ctx, client, project, id, n.(string)); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	return resourceStaticIPRead(ctx, d, m)
+}
+
+func resourceStaticIPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+	project, id := splitResourceID2(d.Id())
+
+	ip, err := getStaticIP(ctx, client, project, id)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+
+	// An address in use by a service cannot be released, static_ips has to be disabled
+	// in the user config of the service first
+	if ip.ServiceName != "" {
+		if err := dissociateStaticIP(ctx, client, project, id); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	err = aivenAPIRequest(ctx, client, http.MethodDelete, aivenAPIPath("project", project, "static-ips", id), nil, nil)
+	if err != nil && !aiven.IsNotFound(err) {
+		return diag.FromErr(err)
+	}
+
+	w := &StaticIPDeleteWaiter{
+		Context: ctx,
+		Client:  client,
+		Project: project,
+		ID:      id,
+	}
+
+	if _, err := w.Conf(d.Timeout(schema.TimeoutDelete)).WaitForStateContext(ctx); err != nil {
+		return diag.Errorf("error waiting for static IP to be deleted: %s", err)
+	}
+
+	return nil
+}
+
+func resourceStaticIPState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	di := resourceStaticIPRead(ctx, d, m)
+	if di.HasError() {
+		return nil, fmt.Errorf("cannot get static IP %v", di)
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func associateStaticIP(ctx context.Context, client *aiven.Client, project, id, serviceName string) error {
+	err := aivenAPIRequest(ctx, client, http.MethodPost,
+		aivenAPIPath("project", project, "static-ips", id, "association"),
+		staticIPAssociateRequest{ServiceName: serviceName}, nil)
+	if err != nil {
+		return fmt.Errorf("cannot associate static IP %s with service %s: %w", id, serviceName, err)
+	}
+
+	return nil
+}
+
+func dissociateStaticIP(ctx context.Context, client *aiven.Client, project, id string) error {
+	err := aivenAPIRequest(ctx, client, http.MethodDelete,
+		aivenAPIPath("project", project, "static-ips", id, "association"), nil, nil)
+	if err != nil && !aiven.IsNotFound(err) {
+		return fmt.Errorf("cannot dissociate static IP %s: %w", id, err)
+	}
+
+	return nil
+}
+
+// StaticIPCreatedWaiter is used to wait for a static IP address to be allocated
+type StaticIPCreatedWaiter struct {
+	Context context.Context
+	Client  *aiven.Client
+	Project string
+	ID      string
+}
+
+// RefreshFunc will call the Aiven client and refresh it's state.
+func (w *StaticIPCreatedWaiter) RefreshFunc() resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		ip, err := getStaticIP(w.Context, w.Client, w.Project, w.ID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		log.Printf("[DEBUG] Got %s state while waiting for static IP to be created.", ip.State)
+
+		return ip, ip.State, nil
+	}
+}
+
+// Conf sets up the configuration to refresh.
+func (w *StaticIPCreatedWaiter) Conf(timeout time.Duration) *resource.StateChangeConf {
+	log.Printf("[DEBUG] Create waiter timeout %.0f minutes", timeout.Minutes())
+
+	return &resource.StateChangeConf{
+		Pending:    []string{"creating"},
+		Target:     []string{"created", "available", "assigned"},
+		Refresh:    w.RefreshFunc(),
+		Timeout:    timeout,
+		MinTimeout: 2 * time.Second,
+	}
+}
+
+// StaticIPDeleteWaiter is used to wait for a static IP address to be released
+type StaticIPDeleteWaiter struct {
+	Context context.Context
+	Client  *aiven.Client
+	Project string
+	ID      string
+}
+
+// RefreshFunc will call the Aiven client and refresh it's state.
+func (w *StaticIPDeleteWaiter) RefreshFunc() resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		ip, err := getStaticIP(w.Context, w.Client, w.Project, w.ID)
+		if err != nil {
+			if aiven.IsNotFound(err) {
+				return struct{}{}, "deleted", nil
+			}
+			return nil, "", err
+		}
+
+		log.Printf("[DEBUG] Got %s state while waiting for static IP to be deleted.", ip.State)
+
+		return ip, ip.State, nil
+	}
+}
+
+// Conf sets up the configuration to refresh.
+func (w *StaticIPDeleteWaiter) Conf(timeout time.Duration) *resource.StateChangeConf {
+	log.Printf("[DEBUG] Delete waiter timeout %.0f minutes", timeout.Minutes())
+
+	return &resource.StateChangeConf{
+		Pending:    []string{"created", "available", "assigned", "deleting"},
+		Target:     []string{"deleted"},
+		Refresh:    w.RefreshFunc(),
+		Timeout:    timeout,
+		MinTimeout: 2 * time.Second,
+	}
+}
@@ -0,0 +1,93 @@
+package aiven
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// fakeStaticIPs is a minimal fake of the static IP endpoints of the Aiven API
+type fakeStaticIPs struct {
+	ips []*staticIP
+}
+
+func (f *fakeStaticIPs) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/project/test/static-ips":
+		_ = json.NewEncoder(w).Encode(staticIPListResponse{StaticIPs: f.ips})
+	case r.Method == http.MethodPost && r.URL.Path == "/project/test/static-ips":
+		var req staticIPCreateRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		ip := &staticIP{CloudName: req.CloudName, IPAddress: "192.0.2.1", State: "created", StaticIPAddressID: "ip1"}
+		f.ips = append(f.ips, ip)
+		_ = json.NewEncoder(w).Encode(ip)
+	case r.Method == http.MethodPost && r.URL.Path == "/project/test/static-ips/ip1/association":
+		var req staticIPAssociateRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		f.ips[0].ServiceName, f.ips[0].State = req.ServiceName, "available"
+	case r.Method == http.MethodDelete && r.URL.Path == "/project/test/static-ips/ip1/association":
+		f.ips[0].ServiceName, f.ips[0].State = "", "created"
+	case r.Method == http.MethodDelete && r.URL.Path == "/project/test/static-ips/ip1":
+		if f.ips[0].ServiceName != "" {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		f.ips = nil
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestStaticIP_lifecycle(t *testing.T) {
+	fake := &fakeStaticIPs{}
+	meta := newTestAivenAPI(t, fake)
+	ctx := context.Background()
+
+	d := schema.TestResourceDataRaw(t, aivenStaticIPSchema, map[string]interface{}{
+		"project":      "test",
+		"cloud_name":   "google-europe-west1",
+		"service_name": "kafka",
+	})
+	if di := resourceStaticIPCreate(ctx, d, meta); di.HasError() {
+		t.Fatal(di)
+	}
+
+	if d.Id() != "test/ip1" || d.Get("ip_address") != "192.0.2.1" || d.Get("service_name") != "kafka" ||
+		d.Get("state") != "available" {
+		t.Errorf("unexpected static IP %s %v %v %v", d.Id(), d.Get("ip_address"), d.Get("service_name"), d.Get("state"))
+	}
+
+	if di := resourceStaticIPDelete(ctx, d, meta); di.HasError() {
+		t.Fatal(di)
+	}
+	if len(fake.ips) != 0 {
+		t.Errorf("expected the static IP to be released, got %v", fake.ips)
+	}
+
+	if di := resourceStaticIPRead(ctx, d, meta); di.HasError() || d.Id() != "" {
+		t.Errorf("expected the static IP to be gone, got %s %v", d.Id(), di)
+	}
+}
+
+func TestStaticIPDeleteWaiter(t *testing.T) {
+	// the address stays assigned for a moment after the association is deleted
+	fake := &fakeStaticIPs{ips: []*staticIP{{StaticIPAddressID: "ip1", State: "assigned"}}}
+	meta := newTestAivenAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fake.ServeHTTP(w, r)
+		if r.Method == http.MethodGet && len(fake.ips) != 0 {
+			fake.ips = nil
+		}
+	}))
+
+	w := &StaticIPDeleteWaiter{Context: context.Background(), Client: meta.client, Project: "test", ID: "ip1"}
+	conf := w.Conf(time.Minute)
+	conf.MinTimeout, conf.Delay = 0, 0
+	conf.PollInterval = 10 * time.Millisecond
+	if _, err := conf.WaitForStateContext(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
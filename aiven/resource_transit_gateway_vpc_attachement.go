@@ -5,7 +5,6 @@ import (
 	"context"
 	"time"
 
-	"github.com/aiven/aiven-go-client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -34,9 +33,10 @@ var aivenTransitGatewayVPCAttachmentSchema = map[string]*schema.Schema{
 		Required:    true,
 		Type:        schema.TypeList,
 		Elem: &schema.Schema{
-			Type:     schema.TypeString,
-			MaxItems: 128,
-			MinItems: 1,
+			Type:         schema.TypeString,
+			MaxItems:     128,
+			MinItems:     1,
+			ValidateFunc: validateNetworkCIDR,
 		},
 	},
 	"peer_region": {
@@ -70,6 +70,7 @@ func resourceTransitGatewayVPCAttachment() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVPCPeeringConnectionImport,
 		},
+		CustomizeDiff: resourceVPCPeeringConnectionCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(2 * time.Minute),
 		},
@@ -79,59 +80,7 @@ func resourceTransitGatewayVPCAttachment() *schema.Resource {
 }
 
 func resourceTransitGatewayVPCAttachmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
-
-	cidrs := flattenToString(d.Get("user_peer_network_cidrs").([]interface{}))
-	projectName, vpcID, peerCloudAccount, peerVPC, _ := parsePeeringVPCId(d.Id())
-
-	peeringConnection, err := client.VPCPeeringConnections.Get(projectName, vpcID, peerCloudAccount, peerVPC)
-	if err != nil {
-		return diag.Errorf("cannot get transit gateway vpc attachment by id %s: %s", d.Id(), err)
-	}
-
-	// prepare a list of new transit gateway vpc attachment that needs to be added
-	var add []aiven.TransitGatewayVPCAttachment
-	for _, fresh := range cidrs {
-		var isNew = true
-
-		for _, old := range peeringConnection.UserPeerNetworkCIDRs {
-			if fresh == old {
-				isNew = false
-				break
-			}
-		}
-
-		if isNew {
-			add = append(add, aiven.TransitGatewayVPCAttachment{
-				CIDR:              fresh,
-				PeerCloudAccount:  peerCloudAccount,
-				PeerResourceGroup: peeringConnection.PeerResourceGroup,
-				PeerVPC:           peerVPC,
-			})
-		}
-	}
-
-	// prepare a list of old cirds for deletion
-	var deleteCIDRs []string
-	for _, old := range peeringConnection.UserPeerNetworkCIDRs {
-		var forDeletion = true
-
-		for _, fresh := range cidrs {
-			if old == fresh {
-				forDeletion = false
-			}
-		}
-
-		if forDeletion {
-			deleteCIDRs = append(deleteCIDRs, old)
-		}
-	}
-
-	_, err = client.TransitGatewayVPCAttachment.Update(projectName, vpcID, aiven.TransitGatewayVPCAttachmentRequest{
-		Add:    add,
-		Delete: deleteCIDRs,
-	})
-	if err != nil {
+	if err := updateUserPeerNetworkCIDRs(d, m.(*providerMeta).client); err != nil {
 		return diag.Errorf("cannot update transit gateway vpc attachment %s", err)
 	}
 
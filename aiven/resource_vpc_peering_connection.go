@@ -75,18 +75,37 @@ var aivenVPCPeeringConnectionSchema = map[string]*schema.Schema{
 		Description: "Azure resource group name of the peered VPC",
 		Type:        schema.TypeString,
 	},
+	"user_peer_network_cidrs": {
+		Description: "List of private IPv4 ranges to route through the peering connection",
+		Optional:    true,
+		Type:        schema.TypeList,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validateNetworkCIDR,
+		},
+	},
+	"accept_on_peer": {
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Accept the peering connection on the peer side using the given cloud credentials",
+		Type:        schema.TypeList,
+		Elem:        &schema.Resource{Schema: aivenVPCPeeringConnectionAcceptOnPeerSchema},
+	},
 }
 
 func resourceVPCPeeringConnection() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceVPCPeeringConnectionCreate,
 		ReadContext:   resourceVPCPeeringConnectionRead,
+		UpdateContext: resourceVPCPeeringConnectionUpdate,
 		DeleteContext: resourceVPCPeeringConnectionDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVPCPeeringConnectionImport,
 		},
+		CustomizeDiff: resourceVPCPeeringConnectionCustomizeDiff,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(2 * time.Minute),
+			Update: schema.DefaultTimeout(2 * time.Minute),
 			Delete: schema.DefaultTimeout(2 * time.Minute),
 		},
 
@@ -102,7 +121,7 @@ func resourceVPCPeeringConnectionCreate(ctx context.Context, d *schema.ResourceD
 		cidrs  []string
 	)
 
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 	projectName, vpcID := splitResourceID2(d.Get("vpc_id").(string))
 	if projectName == "" || vpcID == "" {
 		return diag.Errorf("incorrect VPC ID, expected structure <PROJECT_NAME>/<VPC_ID>")
@@ -185,7 +204,27 @@ func resourceVPCPeeringConnectionCreate(ctx context.Context, d *schema.ResourceD
 
 	diags := diag.Diagnostics{}
 	pc = res.(*aiven.VPCPeeringConnection)
-	if pc.State != "ACTIVE" {
+
+	// When the peer side credentials are given the peering connection is accepted in
+	// the user cloud account and the creation continues until it is active
+	if pc.State == "PENDING_PEER" {
+		acceptor, err := vpcPeeringConnectionAcceptor(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if acceptor != nil {
+			accepted, err := acceptVPCPeeringConnection(
+				ctx, d, client, acceptor, pc, projectName, vpcID, d.Timeout(schema.TimeoutCreate))
+			if err != nil {
+				diags = append(diags, diag.FromErr(err)...)
+			} else {
+				pc = accepted
+			}
+		}
+	}
+
+	if !diags.HasError() && pc.State != "ACTIVE" {
 		switch pc.State {
 		case "PENDING_PEER":
 			diags = append(diags, diag.Diagnostic{
@@ -270,7 +309,7 @@ func parsePeeringVPCId(resourceID string) (string, string, string, string, *stri
 
 func resourceVPCPeeringConnectionRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var pc *aiven.VPCPeeringConnection
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, vpcID, peerCloudAccount, peerVPC, peerRegion := parsePeeringVPCId(d.Id())
 	isAzure, err := isAzureVPCPeeringConnection(d, client)
@@ -303,8 +342,45 @@ func resourceVPCPeeringConnectionRead(_ context.Context, d *schema.ResourceData,
 	return copyVPCPeeringConnectionPropertiesFromAPIResponseToTerraform(d, pc, projectName, vpcID)
 }
 
+func resourceVPCPeeringConnectionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).client
+
+	if d.HasChange("user_peer_network_cidrs") {
+		if err := updateUserPeerNetworkCIDRs(d, client); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// Peer side credentials added to a connection that is still waiting for the peer
+	// are used to accept it right away, otherwise they are only kept for the future
+	if d.HasChange("accept_on_peer") && d.Get("state").(string) == "PENDING_PEER" {
+		acceptor, err := vpcPeeringConnectionAcceptor(d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		if acceptor != nil {
+			projectName, vpcID, peerCloudAccount, peerVPC, peerRegion := parsePeeringVPCId(d.Id())
+			pc, err := client.VPCPeeringConnections.GetVPCPeeringWithResourceGroup(
+				projectName, vpcID, peerCloudAccount, peerVPC, peerRegion, d.Get("peer_resource_group").(string))
+			if err != nil {
+				return diag.Errorf("cannot get VPC peering connection: %s", err)
+			}
+
+			if pc.State == "PENDING_PEER" {
+				if _, err := acceptVPCPeeringConnection(
+					ctx, d, client, acceptor, pc, projectName, vpcID, d.Timeout(schema.TimeoutUpdate)); err != nil {
+					return diag.FromErr(err)
+				}
+			}
+		}
+	}
+
+	return resourceVPCPeeringConnectionRead(ctx, d, m)
+}
+
 func resourceVPCPeeringConnectionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*aiven.Client)
+	client := m.(*providerMeta).client
 
 	projectName, vpcID, peerCloudAccount, peerVPC, peerRegion := parsePeeringVPCId(d.Id())
 
@@ -500,21 +576,20 @@ func copyVPCPeeringConnectionPropertiesFromAPIResponseToTerraform(
 		})
 	}
 
-	// user_peer_network_cidrs filed is only available for transit gateway vpc attachment
-	if len(peeringConnection.UserPeerNetworkCIDRs) > 0 {
-		// convert cidrs from []string to []interface {}
-		cidrs := make([]interface{}, len(peeringConnection.UserPeerNetworkCIDRs))
-		for i, cidr := range peeringConnection.UserPeerNetworkCIDRs {
-			cidrs[i] = cidr
-		}
+	// user_peer_network_cidrs are the ranges stored by Aiven, the API does not report the
+	// routes in effect in the clouds; they are always set to detect changes made outside
+	// of Terraform
+	cidrs := make([]interface{}, len(peeringConnection.UserPeerNetworkCIDRs))
+	for i, cidr := range peeringConnection.UserPeerNetworkCIDRs {
+		cidrs[i] = cidr
+	}
 
-		if err := d.Set("user_peer_network_cidrs", cidrs); err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  fmt.Sprintf("Unable to set user_peer_network_cidrs field: %s", err),
-				Detail:   fmt.Sprintf("Unable to set user_peer_network_cidrs field for VPC peering connection: %s", err),
-			})
-		}
+	if err := d.Set("user_peer_network_cidrs", cidrs); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unable to set user_peer_network_cidrs field: %s", err),
+			Detail:   fmt.Sprintf("Unable to set user_peer_network_cidrs field for VPC peering connection: %s", err),
+		})
 	}
 
 	return diags
@@ -563,3 +638,113 @@ func isAzureVPCPeeringConnection(d *schema.ResourceData, c *aiven.Client) (bool,
 
 	return false, nil
 }
+
+// updateUserPeerNetworkCIDRs updates the list of user peer network CIDRs of a peering
+// connection in place, only the difference between the current and the new list is sent
+func updateUserPeerNetworkCIDRs(d *schema.ResourceData, client *aiven.Client) error {
+	cidrs := flattenToString(d.Get("user_peer_network_cidrs").([]interface{}))
+	projectName, vpcID, peerCloudAccount, peerVPC, peerRegion := parsePeeringVPCId(d.Id())
+
+	peeringConnection, err := client.VPCPeeringConnections.GetVPCPeering(
+		projectName, vpcID, peerCloudAccount, peerVPC, peerRegion)
+	if err != nil {
+		return fmt.Errorf("cannot get VPC peering connection by id %s: %w", d.Id(), err)
+	}
+
+	// prepare a list of new cidrs that needs to be added
+	var add []aiven.TransitGatewayVPCAttachment
+	for _, fresh := range cidrs {
+		var isNew = true
+
+		for _, old := range peeringConnection.UserPeerNetworkCIDRs {
+			if fresh == old {
+				isNew = false
+				break
+			}
+		}
+
+		if isNew {
+			add = append(add, aiven.TransitGatewayVPCAttachment{
+				CIDR:              fresh,
+				PeerCloudAccount:  peerCloudAccount,
+				PeerResourceGroup: peeringConnection.PeerResourceGroup,
+				PeerVPC:           peerVPC,
+			})
+		}
+	}
+
+	// prepare a list of old cidrs for deletion
+	var deleteCIDRs []string
+	for _, old := range peeringConnection.UserPeerNetworkCIDRs {
+		var forDeletion = true
+
+		for _, fresh := range cidrs {
+			if old == fresh {
+				forDeletion = false
+			}
+		}
+
+		if forDeletion {
+			deleteCIDRs = append(deleteCIDRs, old)
+		}
+	}
+
+	if len(add) == 0 && len(deleteCIDRs) == 0 {
+		return nil
+	}
+
+	_, err = client.TransitGatewayVPCAttachment.Update(projectName, vpcID, aiven.TransitGatewayVPCAttachmentRequest{
+		Add:    add,
+		Delete: deleteCIDRs,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot update user peer network CIDRs: %w", err)
+	}
+
+	return nil
+}
+
+// resourceVPCPeeringConnectionCustomizeDiff detects user peer network CIDRs that overlap with
+// the project VPC network or with networks of other peering connections of the same VPC
+func resourceVPCPeeringConnectionCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("user_peer_network_cidrs") || !d.NewValueKnown("user_peer_network_cidrs") || !d.NewValueKnown("vpc_id") {
+		return nil
+	}
+
+	cidrs := flattenToString(d.Get("user_peer_network_cidrs").([]interface{}))
+	if len(cidrs) == 0 {
+		return nil
+	}
+
+	projectName, vpcID := splitResourceID2(d.Get("vpc_id").(string))
+	if projectName == "" || vpcID == "" {
+		return nil
+	}
+
+	vpc, err := m.(*providerMeta).client.VPCs.Get(projectName, vpcID)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("cannot get project VPC: %w", err)
+	}
+
+	peerCloudAccount := d.Get("peer_cloud_account").(string)
+	peerVPC := d.Get("peer_vpc").(string)
+
+	var others []namedCIDR
+	for _, pc := range vpc.PeeringConnections {
+		if pc.PeerCloudAccount == peerCloudAccount && pc.PeerVPC == peerVPC {
+			continue
+		}
+
+		for _, c := range pc.UserPeerNetworkCIDRs {
+			others = append(others, namedCIDR{
+				cidr:  c,
+				owner: fmt.Sprintf("peering connection to %s/%s", pc.PeerCloudAccount, pc.PeerVPC),
+			})
+		}
+	}
+
+	return validatePeerNetworkCIDRs(cidrs, vpc.NetworkCIDR, others)
+}
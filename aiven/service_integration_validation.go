@@ -0,0 +1,333 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serviceIntegrationSide describes what is accepted on one side (source or destination)
+// of a service integration. A nil list of service types means that any service type is
+// accepted, an empty (non-nil) list means that a service cannot be used on this side.
+// The same applies to endpoint types.
+type serviceIntegrationSide struct {
+	serviceTypes  []string
+	endpointTypes []string
+}
+
+// serviceIntegrationRule describes a valid combination of source and destination
+// for a particular integration type
+type serviceIntegrationRule struct {
+	source      serviceIntegrationSide
+	destination serviceIntegrationSide
+	// sameServiceType requires source and destination services to be of the same type
+	sameServiceType bool
+}
+
+var noTypes = []string{}
+
+// serviceIntegrationRules is a validation matrix of the source and destination of
+// integration types, types of the templates missing here are not validated
+var serviceIntegrationRules = map[string]serviceIntegrationRule{
+	"dashboard": {
+		source:      serviceIntegrationSide{serviceTypes: []string{"grafana"}, endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: []string{"influxdb", "m3db", "pg", "mysql", "elasticsearch", "opensearch"}, endpointTypes: noTypes},
+	},
+	"datadog": {
+		source:      serviceIntegrationSide{endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: noTypes, endpointTypes: []string{"datadog"}},
+	},
+	"external_aws_cloudwatch_logs": {
+		source:      serviceIntegrationSide{endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: noTypes, endpointTypes: []string{"external_aws_cloudwatch_logs"}},
+	},
+	"external_aws_cloudwatch_metrics": {
+		source:      serviceIntegrationSide{endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: noTypes, endpointTypes: []string{"external_aws_cloudwatch_metrics"}},
+	},
+	"external_elasticsearch_logs": {
+		source:      serviceIntegrationSide{endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: noTypes, endpointTypes: []string{"external_elasticsearch_logs"}},
+	},
+	"external_google_cloud_logging": {
+		source:      serviceIntegrationSide{endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: noTypes, endpointTypes: []string{"external_google_cloud_logging"}},
+	},
+	"jolokia": {
+		source:      serviceIntegrationSide{serviceTypes: []string{"kafka"}, endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: noTypes, endpointTypes: []string{"jolokia"}},
+	},
+	"kafka_connect": {
+		source:      serviceIntegrationSide{serviceTypes: []string{"kafka"}, endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: []string{"kafka_connect"}, endpointTypes: noTypes},
+	},
+	"kafka_logs": {
+		source:      serviceIntegrationSide{endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: []string{"kafka"}, endpointTypes: noTypes},
+	},
+	"kafka_mirrormaker": {
+		source:      serviceIntegrationSide{serviceTypes: []string{"kafka"}, endpointTypes: []string{"external_kafka"}},
+		destination: serviceIntegrationSide{serviceTypes: []string{"kafka_mirrormaker"}, endpointTypes: noTypes},
+	},
+	"logs": {
+		source:      serviceIntegrationSide{endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: []string{"elasticsearch", "opensearch"}, endpointTypes: noTypes},
+	},
+	"m3aggregator": {
+		source:      serviceIntegrationSide{serviceTypes: []string{"m3aggregator"}, endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: []string{"m3db"}, endpointTypes: noTypes},
+	},
+	"m3coordinator": {
+		source:      serviceIntegrationSide{endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: []string{"m3db"}, endpointTypes: noTypes},
+	},
+	"metrics": {
+		source:      serviceIntegrationSide{endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: []string{"influxdb", "m3db", "pg"}, endpointTypes: noTypes},
+	},
+	"mirrormaker": {
+		source:      serviceIntegrationSide{serviceTypes: []string{"kafka"}, endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: []string{"kafka"}, endpointTypes: noTypes},
+	},
+	"prometheus": {
+		source:      serviceIntegrationSide{endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: noTypes, endpointTypes: []string{"prometheus"}},
+	},
+	"read_replica": {
+		source:          serviceIntegrationSide{serviceTypes: []string{"pg", "mysql", "redis"}, endpointTypes: noTypes},
+		destination:     serviceIntegrationSide{serviceTypes: []string{"pg", "mysql", "redis"}, endpointTypes: noTypes},
+		sameServiceType: true,
+	},
+	"rsyslog": {
+		source:      serviceIntegrationSide{endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: noTypes, endpointTypes: []string{"rsyslog"}},
+	},
+	"schema_registry_proxy": {
+		source:      serviceIntegrationSide{serviceTypes: []string{"kafka"}, endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: noTypes, endpointTypes: []string{"external_schema_registry"}},
+	},
+	"signalfx": {
+		source:      serviceIntegrationSide{serviceTypes: []string{"kafka"}, endpointTypes: noTypes},
+		destination: serviceIntegrationSide{serviceTypes: noTypes, endpointTypes: []string{"signalfx"}},
+	},
+}
+
+// serviceIntegrationTarget is a resolved source or destination of a service integration,
+// an empty type means that it is not known at plan time
+type serviceIntegrationTarget struct {
+	name       string
+	isEndpoint bool
+	typ        string
+}
+
+func (t serviceIntegrationTarget) String() string {
+	kind := "service"
+	if t.isEndpoint {
+		kind = "endpoint"
+	}
+
+	if t.typ == "" {
+		return fmt.Sprintf("%s `%s`", kind, t.name)
+	}
+
+	return fmt.Sprintf("%s `%s` of type `%s`", kind, t.name, t.typ)
+}
+
+func resourceServiceIntegrationCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	integrationType := d.Get("integration_type").(string)
+	if !d.NewValueKnown("integration_type") || integrationType == "" {
+		return nil
+	}
+
+	if !isServiceIntegrationType(integrationType) {
+		return fmt.Errorf("unsupported integration type `%s`, supported types are: %s",
+			integrationType, strings.Join(serviceIntegrationTypes(), ", "))
+	}
+
+	if err := validateServiceIntegrationUserConfig(d, integrationType); err != nil {
+		return err
+	}
+
+	// Source and destination are only looked up when they are about to change, so that
+	// plans of existing integrations do not depend on the API
+	if d.Id() != "" && !serviceIntegrationTargetsChange(d) {
+		return nil
+	}
+
+	// Integration types without a rule are passed to the API as is
+	rule, ok := serviceIntegrationRules[integrationType]
+	if !ok {
+		return nil
+	}
+
+	client := m.(*providerMeta).client
+	projectName := d.Get("project").(string)
+
+	source, err := resolveServiceIntegrationTarget(client, d, projectName, "source_service_name", "source_endpoint_id")
+	if err != nil {
+		return fmt.Errorf("invalid source of `%s` integration: %w", integrationType, err)
+	}
+
+	destination, err := resolveServiceIntegrationTarget(client, d, projectName, "destination_service_name", "destination_endpoint_id")
+	if err != nil {
+		return fmt.Errorf("invalid destination of `%s` integration: %w", integrationType, err)
+	}
+
+	return validateServiceIntegration(integrationType, rule, source, destination)
+}
+
+func serviceIntegrationTargetsChange(d *schema.ResourceDiff) bool {
+	for _, k := range []string{"integration_type", "source_service_name", "source_endpoint_id",
+		"destination_service_name", "destination_endpoint_id"} {
+		if d.HasChange(k) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateServiceIntegrationUserConfig checks that only a user config block matching
+// the integration type is set
+func validateServiceIntegrationUserConfig(d *schema.ResourceDiff, integrationType string) error {
+	for k := range aivenServiceIntegrationSchema {
+		if !strings.HasSuffix(k, "_user_config") || k == integrationType+"_user_config" {
+			continue
+		}
+
+		if v, ok := d.GetOk(k); ok && len(v.([]interface{})) > 0 {
+			return fmt.Errorf("`%s` cannot be used with integration type `%s`, only `%s_user_config` is allowed",
+				k, integrationType, integrationType)
+		}
+	}
+
+	return nil
+}
+
+// resolveServiceIntegrationTarget makes sure that exactly one of the service name and
+// endpoint id is set and looks up the type of the referenced service or endpoint
+func resolveServiceIntegrationTarget(
+	client *aiven.Client,
+	d *schema.ResourceDiff,
+	projectName, serviceKey, endpointKey string,
+) (*serviceIntegrationTarget, error) {
+	serviceName := d.Get(serviceKey).(string)
+	endpointID := d.Get(endpointKey).(string)
+	hasService := serviceName != "" || !d.NewValueKnown(serviceKey)
+	hasEndpoint := endpointID != "" || !d.NewValueKnown(endpointKey)
+
+	if hasService && hasEndpoint {
+		return nil, fmt.Errorf("only one of `%s` and `%s` can be set", serviceKey, endpointKey)
+	}
+	if !hasService && !hasEndpoint {
+		return nil, fmt.Errorf("exactly one of `%s` and `%s` must be set", serviceKey, endpointKey)
+	}
+
+	// The value is going to be known only after apply, for example, when it references a
+	// resource that is created in the same plan; the type cannot be checked then
+	if serviceName == "" && endpointID == "" {
+		return &serviceIntegrationTarget{isEndpoint: hasEndpoint}, nil
+	}
+
+	if hasService {
+		t := &serviceIntegrationTarget{name: serviceName}
+		if !d.NewValueKnown("project") {
+			return t, nil
+		}
+
+		service, err := client.Services.Get(projectName, serviceName)
+		if err != nil {
+			if aiven.IsNotFound(err) {
+				return t, nil
+			}
+			return nil, fmt.Errorf("cannot get service `%s`: %w", serviceName, err)
+		}
+		t.typ = service.Type
+
+		return t, nil
+	}
+
+	endpointProject, plainID := splitResourceID2(endpointID)
+	t := &serviceIntegrationTarget{name: plainID, isEndpoint: true}
+	endpoint, err := client.ServiceIntegrationEndpoints.Get(endpointProject, plainID)
+	if err != nil {
+		if aiven.IsNotFound(err) {
+			return t, nil
+		}
+		return nil, fmt.Errorf("cannot get service integration endpoint `%s`: %w", endpointID, err)
+	}
+	t.typ = endpoint.EndpointType
+
+	return t, nil
+}
+
+// validateServiceIntegration checks resolved source and destination against the rule
+// of the integration type
+func validateServiceIntegration(
+	integrationType string,
+	rule serviceIntegrationRule,
+	source, destination *serviceIntegrationTarget,
+) error {
+	if err := validateServiceIntegrationSide(rule.source, source); err != nil {
+		return fmt.Errorf("invalid source of `%s` integration: %w", integrationType, err)
+	}
+
+	if err := validateServiceIntegrationSide(rule.destination, destination); err != nil {
+		return fmt.Errorf("invalid destination of `%s` integration: %w", integrationType, err)
+	}
+
+	if rule.sameServiceType && source.typ != "" && destination.typ != "" && source.typ != destination.typ {
+		return fmt.Errorf("`%s` integration requires source and destination services of the same type, got %s and %s",
+			integrationType, source, destination)
+	}
+
+	return nil
+}
+
+func validateServiceIntegrationSide(side serviceIntegrationSide, t *serviceIntegrationTarget) error {
+	allowed := side.serviceTypes
+	if t.isEndpoint {
+		allowed = side.endpointTypes
+	}
+
+	if allowed != nil && len(allowed) == 0 {
+		if t.isEndpoint {
+			return fmt.Errorf("an integration endpoint cannot be used, a service is required")
+		}
+		return fmt.Errorf("a service cannot be used, an integration endpoint is required")
+	}
+
+	if allowed == nil || t.typ == "" {
+		return nil
+	}
+
+	for _, a := range allowed {
+		if a == t.typ {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not supported, supported types are: %s", t, strings.Join(allowed, ", "))
+}
+
+// serviceIntegrationTypes lists the integration types of the user config templates
+func serviceIntegrationTypes() []string {
+	var types []string
+	for k := range templates.GetUserConfigSchema("integration") {
+		types = append(types, k)
+	}
+	sort.Strings(types)
+
+	return types
+}
+
+func isServiceIntegrationType(integrationType string) bool {
+	_, ok := templates.GetUserConfigSchema("integration")[integrationType]
+	return ok
+}
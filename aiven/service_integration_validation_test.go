@@ -0,0 +1,120 @@
+package aiven
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestValidateServiceIntegration(t *testing.T) {
+	tests := []struct {
+		name            string
+		integrationType string
+		source          *serviceIntegrationTarget
+		destination     *serviceIntegrationTarget
+		wantErr         bool
+	}{
+		{
+			"metrics pg to influxdb",
+			"metrics",
+			&serviceIntegrationTarget{name: "pg1", typ: "pg"},
+			&serviceIntegrationTarget{name: "influx1", typ: "influxdb"},
+			false,
+		},
+		{
+			"metrics to unsupported destination",
+			"metrics",
+			&serviceIntegrationTarget{name: "pg1", typ: "pg"},
+			&serviceIntegrationTarget{name: "redis1", typ: "redis"},
+			true,
+		},
+		{
+			"metrics to an endpoint",
+			"metrics",
+			&serviceIntegrationTarget{name: "pg1", typ: "pg"},
+			&serviceIntegrationTarget{name: "abc", isEndpoint: true, typ: "datadog"},
+			true,
+		},
+		{
+			"datadog to datadog endpoint",
+			"datadog",
+			&serviceIntegrationTarget{name: "kafka1", typ: "kafka"},
+			&serviceIntegrationTarget{name: "abc", isEndpoint: true, typ: "datadog"},
+			false,
+		},
+		{
+			"datadog to prometheus endpoint",
+			"datadog",
+			&serviceIntegrationTarget{name: "kafka1", typ: "kafka"},
+			&serviceIntegrationTarget{name: "abc", isEndpoint: true, typ: "prometheus"},
+			true,
+		},
+		{
+			"kafka_mirrormaker from external kafka endpoint",
+			"kafka_mirrormaker",
+			&serviceIntegrationTarget{name: "abc", isEndpoint: true, typ: "external_kafka"},
+			&serviceIntegrationTarget{name: "mm1", typ: "kafka_mirrormaker"},
+			false,
+		},
+		{
+			"kafka_connect with unknown types",
+			"kafka_connect",
+			&serviceIntegrationTarget{name: "kafka1"},
+			&serviceIntegrationTarget{},
+			false,
+		},
+		{
+			"read_replica of different types",
+			"read_replica",
+			&serviceIntegrationTarget{name: "pg1", typ: "pg"},
+			&serviceIntegrationTarget{name: "mysql1", typ: "mysql"},
+			true,
+		},
+		{
+			"read_replica of the same type",
+			"read_replica",
+			&serviceIntegrationTarget{name: "pg1", typ: "pg"},
+			&serviceIntegrationTarget{name: "pg2", typ: "pg"},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, ok := serviceIntegrationRules[tt.integrationType]
+			if !ok {
+				t.Fatalf("no rule for integration type %s", tt.integrationType)
+			}
+
+			err := validateServiceIntegration(tt.integrationType, rule, tt.source, tt.destination)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateServiceIntegration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceIntegrationRulesMatchTemplates(t *testing.T) {
+	for integrationType := range serviceIntegrationRules {
+		if !isServiceIntegrationType(integrationType) {
+			t.Errorf("integration type %s has a validation rule but is not in the templates", integrationType)
+		}
+	}
+
+	types := serviceIntegrationTypes()
+	for _, integrationType := range []string{"alertmanager", "datasource", "flink", "internal_connectivity"} {
+		i := sort.SearchStrings(types, integrationType)
+		if i == len(types) || types[i] != integrationType {
+			t.Errorf("integration type %s of the templates is not supported", integrationType)
+		}
+	}
+
+	for k := range aivenServiceIntegrationSchema {
+		if !strings.HasSuffix(k, "_user_config") {
+			continue
+		}
+
+		if integrationType := strings.TrimSuffix(k, "_user_config"); !isServiceIntegrationType(integrationType) {
+			t.Errorf("integration type %s has a user config but is not in the templates", integrationType)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serviceHoursPerMonth is the number of hours Aiven bills a service for in a month
+const serviceHoursPerMonth = 730
+
+// servicePlanRegion is the price of a service plan in one cloud, aiven-go-client v1.6.1
+// has no service type endpoints so they are called through aivenAPIRequest
+type servicePlanRegion struct {
+	PriceUSD string `json:"price_usd"`
+}
+
+type servicePlanPricing struct {
+	ServicePlan string                       `json:"service_plan"`
+	Regions     map[string]servicePlanRegion `json:"regions"`
+}
+
+type serviceTypePricing struct {
+	ServicePlans []servicePlanPricing `json:"service_plans"`
+}
+
+type serviceTypesResponse struct {
+	aiven.APIResponse
+	ServiceTypes map[string]serviceTypePricing `json:"service_types"`
+}
+
+// getServiceTypesPricing returns the service types of a project, they are cached in the
+// provider meta
+func getServiceTypesPricing(ctx context.Context, m interface{}, project string) (map[string]serviceTypePricing, error) {
+	meta := m.(*providerMeta)
+	if v, ok := meta.servicePricing.Load(project); ok {
+		return v.(map[string]serviceTypePricing), nil
+	}
+
+	var r serviceTypesResponse
+	err := aivenAPIRequest(ctx, meta.client, http.MethodGet, aivenAPIPath("project", project, "service-types"), nil, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	meta.servicePricing.Store(project, r.ServiceTypes)
+	return r.ServiceTypes, nil
+}
+
+// serviceMonthlyPrice returns the monthly USD price of a service plan in a cloud
+func serviceMonthlyPrice(pricing map[string]serviceTypePricing, serviceType, plan, cloud string) (float64, error) {
+	for _, p := range pricing[serviceType].ServicePlans {
+		if p.ServicePlan != plan {
+			continue
+		}
+
+		region, ok := p.Regions[cloud]
+		if !ok {
+			return 0, fmt.Errorf("%s plan %s is not available in cloud %s", serviceType, plan, cloud)
+		}
+
+		hourly, err := strconv.ParseFloat(region.PriceUSD, 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot parse %s plan %s price `%s`: %w", serviceType, plan, region.PriceUSD, err)
+		}
+
+		return hourly * serviceHoursPerMonth, nil
+	}
+
+	return 0, fmt.Errorf("%s plan %s does not exist", serviceType, plan)
+}
+
+// setServiceMonthlyPrice sets the monthly price of the plan and cloud of the service
+func setServiceMonthlyPrice(ctx context.Context, d *schema.ResourceData, m interface{}, service *aiven.Service) error {
+	// the generic aiven_service resource has no monthly price
+	if d.Get("monthly_price_usd") == nil {
+		return nil
+	}
+
+	var monthlyPrice string
+	pricing, err := getServiceTypesPricing(ctx, m, d.Get("project").(string))
+	if err == nil {
+		var price float64
+		if price, err = serviceMonthlyPrice(pricing, service.Type, service.Plan, service.CloudName); err == nil {
+			monthlyPrice = strconv.FormatFloat(price, 'f', 2, 64)
+		}
+	}
+	if err != nil {
+		log.Printf("[WARN] cannot get the price of service %s: %s", service.Name, err)
+	}
+
+	return d.Set("monthly_price_usd", monthlyPrice)
+}
+
+// resourceServicePriceCustomizeDiff plans the monthly price of a new service or of the
+// new plan or cloud_name of a service, the plan shows the change from the current price;
+// the plan fails when the price exceeds max_monthly_cost_per_service of the provider
+func resourceServicePriceCustomizeDiff(serviceType string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Id() != "" && !d.HasChange("plan") && !d.HasChange("cloud_name") {
+			return nil
+		}
+
+		plan, cloud := d.Get("plan").(string), d.Get("cloud_name").(string)
+		// Aiven picks the defaults of a service created without plan or cloud_name
+		if !d.NewValueKnown("plan") || !d.NewValueKnown("cloud_name") || !d.NewValueKnown("project") ||
+			plan == "" || cloud == "" {
+			return d.SetNewComputed("monthly_price_usd")
+		}
+
+		maxCost := m.(*providerMeta).maxMonthlyCostPerService
+		pricing, err := getServiceTypesPricing(ctx, m, d.Get("project").(string))
+		if err == nil {
+			var price float64
+			if price, err = serviceMonthlyPrice(pricing, serviceType, plan, cloud); err == nil {
+				if maxCost != 0 && price > maxCost {
+					return fmt.Errorf("%s plan %s in cloud %s costs %.2f USD a month, more than max_monthly_cost_per_service %.2f USD",
+						serviceType, plan, cloud, price, maxCost)
+				}
+
+				return d.SetNew("monthly_price_usd", strconv.FormatFloat(price, 'f', 2, 64))
+			}
+		}
+
+		if maxCost != 0 {
+			return fmt.Errorf("cannot check max_monthly_cost_per_service: %w", err)
+		}
+
+		log.Printf("[WARN] cannot get the price of %s plan %s: %s", serviceType, plan, err)
+		return d.SetNewComputed("monthly_price_usd")
+	}
+}
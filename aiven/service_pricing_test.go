@@ -0,0 +1,57 @@
+package aiven
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_setServiceMonthlyPrice(t *testing.T) {
+	calls := 0
+	meta := newTestAivenAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/project/test-project/service-types" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"service_types": {"pg": {"service_plans": [
+			{"service_plan": "startup-4", "regions": {"google-europe-west1": {"price_usd": "0.1000"}}}
+		]}}}`))
+	}))
+
+	service := &aiven.Service{Name: "pg1", Type: "pg", Plan: "startup-4", CloudName: "google-europe-west1"}
+	for _, tt := range []struct {
+		name   string
+		schema map[string]*schema.Schema
+		want   interface{}
+	}{
+		{"typed", aivenPGSchema(), "73.00"},
+		{"generic", aivenServiceSchema, nil},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, tt.schema, map[string]interface{}{
+				"project":      "test-project",
+				"service_name": "pg1",
+			})
+
+			if err := setServiceMonthlyPrice(context.Background(), d, meta, service); err != nil {
+				t.Fatalf("setServiceMonthlyPrice() error = %v", err)
+			}
+			if got := d.Get("monthly_price_usd"); got != tt.want {
+				t.Errorf("monthly_price_usd = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if calls != 1 {
+		t.Errorf("service types were requested %d times, want once", calls)
+	}
+
+	if _, err := serviceMonthlyPrice(map[string]serviceTypePricing{}, "pg", "startup-4", "google-europe-west1"); err == nil {
+		t.Error("serviceMonthlyPrice() of a missing plan succeeded")
+	}
+}
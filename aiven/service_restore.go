@@ -0,0 +1,274 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/aiven/templates"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// serviceRestoreFromSchema is the restore_from block of the typed resources of the service
+// types whose user config supports service_to_fork_from
+func serviceRestoreFromSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		ForceNew:    true,
+		MaxItems:    1,
+		Description: "Create the service from a backup of another service",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"project": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Project of the source service, defaults to the project of the service",
+				},
+				"service_name": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Service to restore the backup of",
+				},
+				"target_time": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "RFC3339 time to recover the source service data to, PostgreSQL and MySQL only",
+					ValidateFunc: validation.IsRFC3339Time,
+				},
+				"backup_name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Name of the backup to restore, defaults to the latest backup",
+				},
+				"source_backup_time": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Time of the source backup the service was restored from",
+				},
+				"restore_time": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Time the service was restored",
+				},
+			},
+		},
+	}
+}
+
+// serviceUserConfigSupports checks if the user config of a service type has the key
+func serviceUserConfigSupports(serviceType, key string) bool {
+	t, ok := templates.GetUserConfigSchema("service")[serviceType].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	properties, ok := t["properties"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+
+	_, ok = properties[key]
+	return ok
+}
+
+// resourceServiceRestoreCustomizeDiff validates the restore_from block of a new service
+// against the service type and the backups of the source service
+func resourceServiceRestoreCustomizeDiff(serviceType string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if d.Id() != "" || d.Get("restore_from.#").(int) == 0 {
+			return nil
+		}
+
+		if !serviceUserConfigSupports(serviceType, "service_to_fork_from") {
+			return fmt.Errorf("restore_from is not supported for %s services", serviceType)
+		}
+
+		targetTime := d.Get("restore_from.0.target_time").(string)
+		if targetTime != "" && !serviceUserConfigSupports(serviceType, "recovery_target_time") {
+			return fmt.Errorf("restore_from.0.target_time is not supported for %s services", serviceType)
+		}
+
+		backupName := d.Get("restore_from.0.backup_name").(string)
+		if backupName != "" && !serviceUserConfigSupports(serviceType, "recovery_basebackup_name") {
+			return fmt.Errorf("restore_from.0.backup_name is not supported for %s services", serviceType)
+		}
+
+		if v, ok := d.GetOk(serviceType + "_user_config.0.service_to_fork_from"); ok && v.(string) != "" {
+			return fmt.Errorf("restore_from cannot be used together with %s_user_config.service_to_fork_from", serviceType)
+		}
+
+		if !d.NewValueKnown("restore_from.0.service_name") || !d.NewValueKnown("restore_from.0.project") ||
+			!d.NewValueKnown("restore_from.0.backup_name") || !d.NewValueKnown("project") {
+			return nil
+		}
+
+		client := m.(*providerMeta).client
+		project, sourceName := serviceRestoreSource(d.Get("project").(string), d.Get("restore_from").([]interface{}))
+		source, err := client.Services.Get(project, sourceName)
+		if err != nil {
+			return fmt.Errorf("cannot get restore_from service %s/%s: %w", project, sourceName, err)
+		}
+
+		if source.Type != serviceType {
+			return fmt.Errorf("cannot restore a %s service from %s service %s/%s", serviceType, source.Type, project, sourceName)
+		}
+
+		if targetTime == "" && backupName == "" {
+			return nil
+		}
+
+		backups, err := listServiceBackups(ctx, client, project, sourceName)
+		if err != nil {
+			return fmt.Errorf("cannot list the backups of restore_from service %s/%s: %w", project, sourceName, err)
+		}
+
+		_, err = serviceRestoreSourceBackup(backups, backupName, targetTime, time.Now())
+		return err
+	}
+}
+
+// serviceRestoreSource returns the project and name of the service to restore from
+func serviceRestoreSource(project string, restoreFrom []interface{}) (string, string) {
+	r := restoreFrom[0].(map[string]interface{})
+	if p := r["project"].(string); p != "" {
+		project = p
+	}
+
+	return project, r["service_name"].(string)
+}
+
+// serviceRestoreSourceBackup returns the backup a service restored with the given backup
+// name or target time starts from, an RFC3339 target time is expected
+func serviceRestoreSourceBackup(backups []serviceBackup, backupName, targetTime string, now time.Time) (*serviceBackup, error) {
+	if backupName != "" {
+		for i := range backups {
+			if backups[i].BackupName == backupName {
+				return &backups[i], nil
+			}
+		}
+
+		return nil, fmt.Errorf("restore_from.0.backup_name %s is not a backup of the service to restore from", backupName)
+	}
+
+	var target time.Time
+	if targetTime != "" {
+		target, _ = time.Parse(time.RFC3339, targetTime)
+	}
+
+	return serviceRestoreBackup(backups, target, now)
+}
+
+// serviceRestoreBackup returns the latest backup taken at or before the target time, a
+// zero target time selects the latest backup; the target time must not be in the future
+func serviceRestoreBackup(backups []serviceBackup, target, now time.Time) (*serviceBackup, error) {
+	if !target.IsZero() && target.After(now) {
+		return nil, fmt.Errorf("restore_from.0.target_time %s is in the future", target.Format(time.RFC3339))
+	}
+
+	var latest *serviceBackup
+	var latestTime, earliestTime time.Time
+	for i := range backups {
+		b := &backups[i]
+		t, err := time.Parse(time.RFC3339, b.BackupTime)
+		if err != nil {
+			return nil, fmt.Errorf("cannot parse backup time `%s`: %w", b.BackupTime, err)
+		}
+
+		if earliestTime.IsZero() || t.Before(earliestTime) {
+			earliestTime = t
+		}
+
+		if !target.IsZero() && t.After(target) {
+			continue
+		}
+
+		if latest == nil || t.After(latestTime) {
+			latest, latestTime = b, t
+		}
+	}
+
+	if len(backups) == 0 {
+		return nil, fmt.Errorf("the service to restore from has no backups")
+	}
+
+	if latest == nil {
+		return nil, fmt.Errorf("restore_from.0.target_time %s is before the earliest backup at %s",
+			target.Format(time.RFC3339), earliestTime.Format(time.RFC3339))
+	}
+
+	return latest, nil
+}
+
+// serviceRestoreUserConfig adds the fork and recovery options of the restore_from block
+// to the user config of a new service
+func serviceRestoreUserConfig(d *schema.ResourceData, userConfig map[string]interface{}) map[string]interface{} {
+	// the generic aiven_service resource has no restore_from block
+	v, ok := d.GetOk("restore_from")
+	if !ok {
+		return userConfig
+	}
+
+	restoreFrom := v.([]interface{})
+	if len(restoreFrom) == 0 || restoreFrom[0] == nil {
+		return userConfig
+	}
+
+	if userConfig == nil {
+		userConfig = make(map[string]interface{})
+	}
+
+	r := restoreFrom[0].(map[string]interface{})
+	project, sourceName := serviceRestoreSource(d.Get("project").(string), restoreFrom)
+	userConfig["service_to_fork_from"] = sourceName
+	userConfig["project_to_fork_from"] = project
+	if v := r["target_time"].(string); v != "" {
+		userConfig["recovery_target_time"] = v
+	}
+	if v := r["backup_name"].(string); v != "" {
+		userConfig["recovery_basebackup_name"] = v
+	}
+
+	return userConfig
+}
+
+// resolveServiceRestoreBackup finds the source backup of a service with a restore_from
+// block before the service is created, so that a failed lookup does not leave a created
+// service behind; nil is returned for other services
+func resolveServiceRestoreBackup(ctx context.Context, d *schema.ResourceData, client *aiven.Client) (*serviceBackup, error) {
+	// the generic aiven_service resource has no restore_from block
+	v, ok := d.GetOk("restore_from")
+	if !ok {
+		return nil, nil
+	}
+
+	restoreFrom := v.([]interface{})
+	if len(restoreFrom) == 0 || restoreFrom[0] == nil {
+		return nil, nil
+	}
+
+	r := restoreFrom[0].(map[string]interface{})
+	project, sourceName := serviceRestoreSource(d.Get("project").(string), restoreFrom)
+	backups, err := listServiceBackups(ctx, client, project, sourceName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the backups of restore_from service %s/%s: %w", project, sourceName, err)
+	}
+
+	return serviceRestoreSourceBackup(backups, r["backup_name"].(string), r["target_time"].(string), time.Now())
+}
+
+// setServiceRestoreLineage records which source backup a restored service was created from
+func setServiceRestoreLineage(d *schema.ResourceData, backup *serviceBackup) error {
+	if backup == nil {
+		return nil
+	}
+
+	r := d.Get("restore_from").([]interface{})[0].(map[string]interface{})
+	r["source_backup_time"] = backup.BackupTime
+	r["restore_time"] = time.Now().UTC().Format(time.RFC3339)
+
+	return d.Set("restore_from", []interface{}{r})
+}
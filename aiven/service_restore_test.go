@@ -0,0 +1,152 @@
+package aiven
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_serviceRestoreBackup(t *testing.T) {
+	backups := []serviceBackup{
+		{BackupName: "backup-2", BackupTime: "2021-09-02T00:00:00Z"},
+		{BackupName: "backup-1", BackupTime: "2021-09-01T00:00:00Z"},
+		{BackupName: "backup-3", BackupTime: "2021-09-03T00:00:00Z"},
+	}
+	now := time.Date(2021, 9, 4, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		target  time.Time
+		want    string
+		wantErr bool
+	}{
+		{"latest", time.Time{}, "2021-09-03T00:00:00Z", false},
+		{"between backups", time.Date(2021, 9, 2, 12, 0, 0, 0, time.UTC), "2021-09-02T00:00:00Z", false},
+		{"at a backup", time.Date(2021, 9, 1, 0, 0, 0, 0, time.UTC), "2021-09-01T00:00:00Z", false},
+		{"before the earliest backup", time.Date(2021, 8, 31, 0, 0, 0, 0, time.UTC), "", true},
+		{"in the future", time.Date(2021, 9, 5, 0, 0, 0, 0, time.UTC), "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := serviceRestoreBackup(backups, tt.target, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("serviceRestoreBackup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.BackupTime != tt.want {
+				t.Errorf("serviceRestoreBackup() = %s, want %s", got.BackupTime, tt.want)
+			}
+		})
+	}
+
+	if _, err := serviceRestoreBackup(nil, time.Time{}, now); err == nil {
+		t.Error("expected an error for a service without backups")
+	}
+
+	got, err := serviceRestoreSourceBackup(backups, "backup-1", "", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.BackupTime != "2021-09-01T00:00:00Z" {
+		t.Errorf("serviceRestoreSourceBackup() = %s, want the time of backup-1", got.BackupTime)
+	}
+	if _, err := serviceRestoreSourceBackup(backups, "backup-4", "", now); err == nil {
+		t.Error("expected an error for a backup name the service does not have")
+	}
+}
+
+func Test_serviceRestoreUserConfig(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, aivenPGSchema(), map[string]interface{}{
+		"project":      "test",
+		"service_name": "pg-restored",
+		"restore_from": []interface{}{map[string]interface{}{
+			"service_name": "pg",
+			"target_time":  "2021-09-02T12:00:00Z",
+		}},
+	})
+
+	got := serviceRestoreUserConfig(d, map[string]interface{}{"pg_version": "13"})
+	want := map[string]interface{}{
+		"pg_version":           "13",
+		"service_to_fork_from": "pg",
+		"project_to_fork_from": "test",
+		"recovery_target_time": "2021-09-02T12:00:00Z",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("serviceRestoreUserConfig() = %v, want %v", got, want)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("serviceRestoreUserConfig()[%s] = %v, want %v", k, got[k], v)
+		}
+	}
+}
+
+func Test_resolveServiceRestoreBackup(t *testing.T) {
+	meta := newTestAivenAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/project/test/service/redis/backups" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message": "not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"backups": [
+			{"backup_name": "backup-1", "backup_time": "2021-09-01T00:00:00Z", "data_size": 1},
+			{"backup_name": "backup-2", "backup_time": "2021-09-02T00:00:00Z", "data_size": 2}
+		]}`))
+	}))
+
+	d := schema.TestResourceDataRaw(t, redisSchema(), map[string]interface{}{
+		"project":      "test",
+		"service_name": "redis-restored",
+		"restore_from": []interface{}{map[string]interface{}{
+			"service_name": "redis",
+			"backup_name":  "backup-1",
+		}},
+	})
+
+	backup, err := resolveServiceRestoreBackup(context.Background(), d, meta.client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := setServiceRestoreLineage(d, backup); err != nil {
+		t.Fatal(err)
+	}
+	if v := d.Get("restore_from.0.source_backup_time").(string); v != "2021-09-01T00:00:00Z" {
+		t.Errorf("source_backup_time = %s, want the time of backup-1", v)
+	}
+	if v := d.Get("restore_from.0.restore_time").(string); v == "" {
+		t.Error("restore_time is not set")
+	}
+
+	d = schema.TestResourceDataRaw(t, redisSchema(), map[string]interface{}{
+		"project":      "test",
+		"service_name": "redis-restored",
+		"restore_from": []interface{}{map[string]interface{}{
+			"service_name": "missing",
+		}},
+	})
+	if _, err := resolveServiceRestoreBackup(context.Background(), d, meta.client); err == nil {
+		t.Error("expected an error for a source service that does not exist")
+	}
+
+	d = schema.TestResourceDataRaw(t, redisSchema(), map[string]interface{}{
+		"project":      "test",
+		"service_name": "redis",
+	})
+	if backup, err := resolveServiceRestoreBackup(context.Background(), d, meta.client); backup != nil || err != nil {
+		t.Errorf("expected no backup for a service without restore_from, got %v, %v", backup, err)
+	}
+}
+
+func Test_serviceRestoreSchemas(t *testing.T) {
+	for name, s := range map[string]map[string]*schema.Schema{
+		"kafka":        aivenKafkaSchema(),
+		"m3aggregator": aivenM3AggregatorSchema(),
+	} {
+		if _, ok := s["restore_from"]; ok {
+			t.Errorf("%s has restore_from, its user config does not support service_to_fork_from", name)
+		}
+	}
+}
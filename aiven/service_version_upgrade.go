@@ -0,0 +1,264 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serviceVersionUserConfigKeys lists the user config keys that hold the version of a service type
+var serviceVersionUserConfigKeys = map[string][]string{
+	ServiceTypePG:            {"pg_version"},
+	ServiceTypeMySQL:         {"mysql_version"},
+	ServiceTypeKafka:         {"kafka_version"},
+	ServiceTypeElasticsearch: {"elasticsearch_version", "opensearch_version"},
+	ServiceTypeOpensearch:    {"opensearch_version"},
+	ServiceTypeCassandra:     {"cassandra_version"},
+	ServiceTypeM3:            {"m3_version", "m3db_version"},
+	ServiceTypeM3Aggregator:  {"m3_version", "m3aggregator_version"},
+}
+
+// serviceUpgradeCheckTypes lists the service types the API can run an upgrade_check task for
+var serviceUpgradeCheckTypes = map[string]bool{
+	ServiceTypePG: true,
+}
+
+// serviceAllowMajorUpgradeSchema is added to the resources of the service types listed in
+// serviceVersionUserConfigKeys
+func serviceAllowMajorUpgradeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+		Description: "Allow changing the service version to a new major version, downgrades are never allowed",
+	}
+}
+
+// resourceServiceVersionCustomizeDiff forbids service version downgrades and, unless
+// allow_major_upgrade is set, major version upgrades at plan time
+func resourceServiceVersionCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	serviceType := d.Get("service_type").(string)
+	var service *aiven.Service
+	for _, key := range serviceVersionUserConfigKeys[serviceType] {
+		path := fmt.Sprintf("%s_user_config.0.%s", serviceType, key)
+		if !d.HasChange(path) || !d.NewValueKnown(path) {
+			continue
+		}
+
+		o, n := d.GetChange(path)
+		current, target := o.(string), n.(string)
+		if target == "" {
+			continue
+		}
+
+		// the version may be missing from the state when it was never configured
+		if current == "" {
+			if service == nil {
+				projectName, serviceName := splitResourceID2(d.Id())
+				s, err := m.(*providerMeta).client.Services.Get(projectName, serviceName)
+				if err != nil {
+					return fmt.Errorf("cannot get a service: %w", err)
+				}
+				service = s
+			}
+
+			if v, ok := service.UserConfig[key].(string); ok {
+				current = v
+			}
+		}
+
+		if err := validateServiceVersionChange(key, current, target, d.Get("allow_major_upgrade").(bool)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateServiceVersionChange checks that a version change is not a downgrade and that
+// major upgrades are allowed
+func validateServiceVersionChange(key, current, target string, allowMajorUpgrade bool) error {
+	if current == "" || current == target {
+		return nil
+	}
+
+	c, err := compareServiceVersions(current, target)
+	if err != nil {
+		return fmt.Errorf("cannot compare %s: %w", key, err)
+	}
+
+	if c > 0 {
+		return fmt.Errorf("%s cannot be downgraded from %s to %s", key, current, target)
+	}
+
+	if !allowMajorUpgrade && isMajorServiceVersionUpgrade(current, target) {
+		return fmt.Errorf("%s major version upgrade from %s to %s requires allow_major_upgrade to be set to true",
+			key, current, target)
+	}
+
+	return nil
+}
+
+// compareServiceVersions compares dot separated numeric versions and returns -1, 0 or 1
+func compareServiceVersions(a, b string) (int, error) {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		var err error
+		if i < len(as) {
+			if x, err = strconv.Atoi(as[i]); err != nil {
+				return 0, fmt.Errorf("invalid version `%s`", a)
+			}
+		}
+		if i < len(bs) {
+			if y, err = strconv.Atoi(bs[i]); err != nil {
+				return 0, fmt.Errorf("invalid version `%s`", b)
+			}
+		}
+
+		switch {
+		case x < y:
+			return -1, nil
+		case x > y:
+			return 1, nil
+		}
+	}
+
+	return 0, nil
+}
+
+// isMajorServiceVersionUpgrade checks if the first version component changes; PostgreSQL
+// 9.x versions are the exception where the first two components form the major version
+func isMajorServiceVersionUpgrade(current, target string) bool {
+	major := func(v string) string {
+		parts := strings.Split(v, ".")
+		if parts[0] == "9" && len(parts) > 1 {
+			return parts[0] + "." + parts[1]
+		}
+		return parts[0]
+	}
+
+	return major(current) != major(target)
+}
+
+// resourceServiceUpgradeCheck runs the API upgrade check for service types that support it
+// when the service version changes; a failed check is returned as an error diagnostic and
+// the result of a passed one as a warning, so that it is shown by the apply
+func resourceServiceUpgradeCheck(ctx context.Context, d *schema.ResourceData, client *aiven.Client, userConfig map[string]interface{}) diag.Diagnostics {
+	serviceType := d.Get("service_type").(string)
+	if !serviceUpgradeCheckTypes[serviceType] {
+		return nil
+	}
+
+	projectName, serviceName := splitResourceID2(d.Id())
+	var service *aiven.Service
+	var diags diag.Diagnostics
+	for _, key := range serviceVersionUserConfigKeys[serviceType] {
+		target, ok := userConfig[key].(string)
+		if !ok || target == "" {
+			continue
+		}
+
+		if service == nil {
+			s, err := client.Services.Get(projectName, serviceName)
+			if err != nil {
+				return diag.Errorf("cannot get a service: %s", err)
+			}
+			service = s
+		}
+
+		current, _ := service.UserConfig[key].(string)
+		if current == target {
+			continue
+		}
+
+		t, err := client.ServiceTask.Create(projectName, serviceName, aiven.ServiceTaskRequest{
+			TargetVersion: target,
+			TaskType:      "upgrade_check",
+		})
+		if err != nil {
+			return diag.Errorf("cannot create %s upgrade check task: %s", serviceType, err)
+		}
+
+		w := &ServiceTaskWaiter{
+			Client:      client,
+			Project:     projectName,
+			ServiceName: serviceName,
+			TaskId:      t.Task.Id,
+		}
+
+		taskI, err := w.Conf(d.Timeout(schema.TimeoutDefault)).WaitForStateContext(ctx)
+		if err != nil {
+			return diag.Errorf("error waiting for Aiven service task to be DONE: %s", err)
+		}
+
+		task := taskI.(*aiven.ServiceTaskResponse)
+		if !*task.Task.Success {
+			return diag.Errorf(
+				"%s service upgrade check error, version upgrade from %s to %s, result: %s",
+				serviceType, current, target, task.Task.Result)
+		}
+
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("%s service upgrade check from %s to %s passed", serviceType, current, target),
+			Detail:   task.Task.Result,
+		})
+	}
+
+	return diags
+}
+
+// ServiceTaskWaiter is used to refresh the Aiven Service Task endpoints when
+// provisioning.
+type ServiceTaskWaiter struct {
+	Client      *aiven.Client
+	Project     string
+	ServiceName string
+	TaskId      string
+}
+
+// RefreshFunc will call the Aiven client and refresh its state.
+func (w *ServiceTaskWaiter) RefreshFunc() resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		t, err := w.Client.ServiceTask.Get(
+			w.Project,
+			w.ServiceName,
+			w.TaskId,
+		)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if t.Task.Success == nil {
+			return nil, "IN_PROGRESS", nil
+		}
+
+		return t, "DONE", nil
+	}
+}
+
+// Conf sets up the configuration to refresh.
+func (w *ServiceTaskWaiter) Conf(timeout time.Duration) *resource.StateChangeConf {
+	return &resource.StateChangeConf{
+		Pending:                   []string{"IN_PROGRESS"},
+		Target:                    []string{"DONE"},
+		Refresh:                   w.RefreshFunc(),
+		Delay:                     10 * time.Second,
+		Timeout:                   timeout,
+		MinTimeout:                2 * time.Second,
+		ContinuousTargetOccurence: 3,
+	}
+}
@@ -0,0 +1,54 @@
+package aiven
+
+import "testing"
+
+func Test_compareServiceVersions(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		want    int
+		wantErr bool
+	}{
+		{"12", "13", -1, false},
+		{"13", "12", 1, false},
+		{"9.6", "10", -1, false},
+		{"2.7", "2.10", -1, false},
+		{"2.8", "2.8", 0, false},
+		{"1", "1.0", 0, false},
+		{"x", "1", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := compareServiceVersions(tt.a, tt.b)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("compareServiceVersions(%s, %s) error = %v, wantErr %v", tt.a, tt.b, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("compareServiceVersions(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func Test_validateServiceVersionChange(t *testing.T) {
+	tests := []struct {
+		name              string
+		current, target   string
+		allowMajorUpgrade bool
+		wantErr           bool
+	}{
+		{"unchanged", "13", "13", false, false},
+		{"unknown current version", "", "13", false, false},
+		{"downgrade", "13", "12", true, true},
+		{"minor downgrade", "2.8", "2.7", true, true},
+		{"major upgrade allowed", "12", "13", true, false},
+		{"major upgrade not allowed", "12", "13", false, true},
+		{"pg 9 major upgrade not allowed", "9.5", "9.6", false, true},
+		{"minor upgrade", "2.7", "2.8", false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateServiceVersionChange("version", tt.current, tt.target, tt.allowMajorUpgrade)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateServiceVersionChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// aivenTags are the key value tags of a project or a service, aiven-go-client v1.6.1 has
+// no tag endpoints so they are called through aivenAPIRequest
+type aivenTags struct {
+	aiven.APIResponse
+	Tags map[string]string `json:"tags"`
+}
+
+// tagsSchema adds the tag block and the computed tags_all map to a resource schema, the
+// tag block has the same shape as the one of aiven_kafka_topic
+func tagsSchema(s map[string]*schema.Schema, resourceName string) map[string]*schema.Schema {
+	s["tag"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "Tags of the " + resourceName,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "Tag key",
+					ValidateFunc: validation.StringLenBetween(1, 64),
+				},
+				"value": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "Tag value",
+					ValidateFunc: validation.StringLenBetween(0, 256),
+				},
+			},
+		},
+	}
+	s["tags_all"] = &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Description: "Tags of the " + resourceName + " including the default_tags of the provider",
+		Elem:        &schema.Schema{Type: schema.TypeString},
+	}
+
+	return s
+}
+
+func projectTagsPath(project string) string {
+	return aivenAPIPath("project", project, "tags")
+}
+
+func serviceTagsPath(project, serviceName string) string {
+	return aivenAPIPath("project", project, "service", serviceName, "tags")
+}
+
+// serviceHasTags tells the typed service resources from the generic aiven_service
+// resource, which has no tags
+func serviceHasTags(d *schema.ResourceData) bool {
+	return d.Get("tag") != nil
+}
+
+// resourceTags merges the tag block of the resource into the default_tags of the provider
+func resourceTags(tag *schema.Set, defaultTags map[string]string) map[string]string {
+	tags := make(map[string]string)
+	for k, v := range defaultTags {
+		tags[k] = v
+	}
+	for _, t := range tag.List() {
+		t := t.(map[string]interface{})
+		tags[t["key"].(string)] = t["value"].(string)
+	}
+
+	return tags
+}
+
+// resourceTagsCustomizeDiff plans tags_all so that default_tags changes of the provider
+// update the resources
+func resourceTagsCustomizeDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("tag") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	tags := resourceTags(d.Get("tag").(*schema.Set), m.(*providerMeta).defaultTags)
+
+	current := make(map[string]string)
+	for k, v := range d.Get("tags_all").(map[string]interface{}) {
+		current[k] = v.(string)
+	}
+
+	if reflect.DeepEqual(tags, current) {
+		return nil
+	}
+
+	return d.SetNew("tags_all", tags)
+}
+
+// updateResourceTags replaces the tags of a project or a service with the merged tags of
+// the resource
+func updateResourceTags(ctx context.Context, d *schema.ResourceData, m interface{}, path string) error {
+	tags := resourceTags(d.Get("tag").(*schema.Set), m.(*providerMeta).defaultTags)
+
+	return aivenAPIRequest(ctx, m.(*providerMeta).client, http.MethodPut, path, aivenTags{Tags: tags}, nil)
+}
+
+// readResourceTags sets tags_all to the tags of a project or a service and the tag block to
+// the tags not coming from default_tags, unless the tag block sets them too
+func readResourceTags(ctx context.Context, d *schema.ResourceData, m interface{}, path string) error {
+	var r aivenTags
+	if err := aivenAPIRequest(ctx, m.(*providerMeta).client, http.MethodGet, path, nil, &r); err != nil {
+		return err
+	}
+
+	configured := make(map[string]bool)
+	for _, t := range d.Get("tag").(*schema.Set).List() {
+		configured[t.(map[string]interface{})["key"].(string)] = true
+	}
+
+	defaultTags := m.(*providerMeta).defaultTags
+	var tag []map[string]interface{}
+	for k, v := range r.Tags {
+		if dv, ok := defaultTags[k]; ok && dv == v && !configured[k] {
+			continue
+		}
+
+		tag = append(tag, map[string]interface{}{"key": k, "value": v})
+	}
+
+	if err := d.Set("tag", tag); err != nil {
+		return err
+	}
+
+	return d.Set("tags_all", r.Tags)
+}
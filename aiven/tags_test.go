@@ -0,0 +1,64 @@
+package aiven
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_resourceTags(t *testing.T) {
+	var put map[string]string
+	meta := newTestAivenAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/project/test-project/service/pg1/tags" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Method == http.MethodPut {
+			var body aivenTags
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			put = body.Tags
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"tags": {"team": "data", "env": "prod", "owner": "db-team", "cost-center": "42"}}`))
+	}))
+	meta.defaultTags = map[string]string{"env": "prod", "owner": "platform", "team": "data"}
+
+	d := schema.TestResourceDataRaw(t, aivenPGSchema(), map[string]interface{}{
+		"project":      "test-project",
+		"service_name": "pg1",
+		"tag": []interface{}{
+			map[string]interface{}{"key": "team", "value": "data"},
+			map[string]interface{}{"key": "owner", "value": "db-team"},
+		},
+	})
+
+	if err := updateResourceTags(context.Background(), d, meta, serviceTagsPath("test-project", "pg1")); err != nil {
+		t.Fatalf("updateResourceTags() error = %v", err)
+	}
+	if want := map[string]string{"team": "data", "env": "prod", "owner": "db-team"}; !reflect.DeepEqual(put, want) {
+		t.Errorf("updateResourceTags() sent %v, want %v", put, want)
+	}
+
+	if err := readResourceTags(context.Background(), d, meta, serviceTagsPath("test-project", "pg1")); err != nil {
+		t.Fatalf("readResourceTags() error = %v", err)
+	}
+
+	// env comes from default_tags, team is set by both but configured on the resource
+	got := make(map[string]string)
+	for _, tag := range d.Get("tag").(*schema.Set).List() {
+		tag := tag.(map[string]interface{})
+		got[tag["key"].(string)] = tag["value"].(string)
+	}
+	if want := map[string]string{"team": "data", "owner": "db-team", "cost-center": "42"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tag = %v, want %v", got, want)
+	}
+	if n := len(d.Get("tags_all").(map[string]interface{})); n != 4 {
+		t.Errorf("tags_all has %d tags, want 4", n)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// terminationProtectionError describes why a protected resource cannot be deleted
+func terminationProtectionError(kind, name, reason string) error {
+	if reason == "" {
+		return fmt.Errorf("cannot delete %s %s, termination_protection is enabled", kind, name)
+	}
+
+	return fmt.Errorf("cannot delete %s %s, termination_protection is enabled: %s", kind, name, reason)
+}
+
+// resourceTerminationProtectionCustomizeDiff fails the plan when a change would replace a
+// resource whose termination_protection is enabled; Terraform does not run CustomizeDiff
+// for destroy plans, those fail when the resource is deleted
+func resourceTerminationProtectionCustomizeDiff(kind, nameKey string, s map[string]*schema.Schema) schema.CustomizeDiffFunc {
+	var forceNew []string
+	for k, v := range s {
+		if v.ForceNew {
+			forceNew = append(forceNew, k)
+		}
+	}
+	sort.Strings(forceNew)
+
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+
+		if protected, _ := d.GetChange("termination_protection"); !protected.(bool) {
+			return nil
+		}
+
+		for _, k := range forceNew {
+			if d.HasChange(k) {
+				name, _ := d.GetChange(nameKey)
+				reason, _ := d.GetChange("prevent_destroy_reason")
+				return fmt.Errorf("changing %s requires replacement: %w",
+					k, terminationProtectionError(kind, name.(string), reason.(string)))
+			}
+		}
+
+		return nil
+	}
+}
+
+// isRetryableDeleteError checks if a failed delete may succeed when retried, conflicts,
+// rate limiting and server errors are transient while other errors are returned
+func isRetryableDeleteError(err error) bool {
+	e, ok := err.(aiven.Error)
+	if !ok {
+		return false
+	}
+
+	return e.Status == 409 || e.Status == 429 || e.Status >= 500
+}
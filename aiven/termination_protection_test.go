@@ -0,0 +1,71 @@
+package aiven
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func Test_isRetryableDeleteError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{aiven.Error{Status: 409}, true},
+		{aiven.Error{Status: 429}, true},
+		{aiven.Error{Status: 503}, true},
+		{aiven.Error{Status: 403}, false},
+		{aiven.Error{Status: 400}, false},
+		{errors.New("connection reset"), false},
+	}
+	for _, tt := range tests {
+		if got := isRetryableDeleteError(tt.err); got != tt.want {
+			t.Errorf("isRetryableDeleteError(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func Test_resourceTerminationProtectionCustomizeDiff(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "test/pg/orders",
+		Attributes: map[string]string{
+			"project":                "test",
+			"service_name":           "pg",
+			"database_name":          "orders",
+			"lc_collate":             defaultLC,
+			"lc_ctype":               defaultLC,
+			"termination_protection": "true",
+			"prevent_destroy_reason": "holds the order history",
+			"require_empty":          "false",
+		},
+	}
+
+	config := func(databaseName string, protected bool) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"project":                "test",
+			"service_name":           "pg",
+			"database_name":          databaseName,
+			"termination_protection": protected,
+			"prevent_destroy_reason": "holds the order history",
+		})
+	}
+
+	_, err := resourceDatabase().SimpleDiff(context.Background(), state, config("orders_v2", true), nil)
+	if err == nil || !strings.Contains(err.Error(), "holds the order history") {
+		t.Errorf("expected replacing a protected database to fail with the reason, got %v", err)
+	}
+
+	// the protection stored in the state applies until it has been disabled
+	_, err = resourceDatabase().SimpleDiff(context.Background(), state, config("orders_v2", false), nil)
+	if err == nil {
+		t.Error("expected replacing a protected database to fail while disabling the protection")
+	}
+
+	if _, err := resourceDatabase().SimpleDiff(context.Background(), state, config("orders", true), nil); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
@@ -31,11 +31,7 @@ func GenerateTerraformUserConfigSchema(data map[string]interface{}) map[string]*
 
 func generateTerraformUserConfigSchema(key string, definition map[string]interface{}) *schema.Schema {
 	valueType := getAivenSchemaType(definition["type"])
-	sensitive := false
-
-	if strings.Contains(key, "api_key") || strings.Contains(key, "password") {
-		sensitive = true
-	}
+	sensitive := isSensitiveUserConfigKey(key)
 
 	var diffFunction schema.SchemaDiffSuppressFunc
 	if createOnly, ok := definition["createOnly"]; ok && createOnly.(bool) {
@@ -109,6 +105,23 @@ func generateTerraformUserConfigSchema(key string, definition map[string]interfa
 	}
 }
 
+// isSensitiveUserConfigKey checks if a user configuration option holds a secret
+// that has to be masked in the plan output
+func isSensitiveUserConfigKey(key string) bool {
+	for _, s := range []string{"api_key", "password", "secret", "credentials"} {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+
+	switch key {
+	case "key", "access_key", "ssl_client_key":
+		return true
+	}
+
+	return false
+}
+
 func getAivenSchemaType(value interface{}) string {
 	switch res := value.(type) {
 	case string:
@@ -241,3 +241,25 @@ func Test_convertTerraformUserConfigToAPICompatibleFormat(t *testing.T) {
 		})
 	}
 }
+
+func Test_isSensitiveUserConfigKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{"datadog_api_key", true},
+		{"sasl_plain_password", true},
+		{"secret_key", true},
+		{"service_account_credentials", true},
+		{"ssl_client_key", true},
+		{"key", true},
+		{"ssl_client_cert", false},
+		{"sql_require_primary_key", false},
+		{"redis_notify_keyspace_events", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.Equal(t, tt.want, isSensitiveUserConfigKey(tt.key))
+		})
+	}
+}
@@ -0,0 +1,283 @@
+// Copyright (c) 2021 Aiven, Helsinki, Finland. https://aiven.io/
+package aiven
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aiven/aiven-go-client"
+	"github.com/aiven/terraform-provider-aiven/pkg/peering"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var aivenVPCPeeringConnectionAcceptOnPeerSchema = map[string]*schema.Schema{
+	"aws": {
+		Description: "Accept AWS VPC peering connection and add routes to the Aiven VPC",
+		Optional:    true,
+		MaxItems:    1,
+		Type:        schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"access_key": {
+					Description: "AWS access key of the peer account",
+					Required:    true,
+					Sensitive:   true,
+					Type:        schema.TypeString,
+				},
+				"secret_key": {
+					Description: "AWS secret key of the peer account",
+					Required:    true,
+					Sensitive:   true,
+					Type:        schema.TypeString,
+				},
+				"session_token": {
+					Description: "AWS session token of the peer account",
+					Optional:    true,
+					Sensitive:   true,
+					Type:        schema.TypeString,
+				},
+				"route_table_ids": {
+					Description: "Route tables of the peered VPC where a route to the Aiven VPC is added",
+					Optional:    true,
+					Type:        schema.TypeList,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"endpoint": {
+					Description: "Custom EC2 API endpoint",
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+			},
+		},
+	},
+	"gcp": {
+		Description: "Create the peer side of Google Cloud VPC network peering",
+		Optional:    true,
+		MaxItems:    1,
+		Type:        schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"access_token": {
+					Description: "Short lived OAuth 2.0 access token with permissions to manage the peered VPC network",
+					Optional:    true,
+					Sensitive:   true,
+					Type:        schema.TypeString,
+				},
+				"credentials": {
+					Description: "Service account key or a path to it, defaults to the Google application default credentials",
+					Optional:    true,
+					Sensitive:   true,
+					Type:        schema.TypeString,
+				},
+				"endpoint": {
+					Description: "Custom Compute Engine API endpoint",
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+			},
+		},
+	},
+	"azure": {
+		Description: "Create the peer side of Azure virtual network peering",
+		Optional:    true,
+		MaxItems:    1,
+		Type:        schema.TypeList,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"access_token": {
+					Description: "Short lived Azure Resource Manager access token of the peer tenant",
+					Optional:    true,
+					Sensitive:   true,
+					Type:        schema.TypeString,
+				},
+				"tenant_id": {
+					Description: "Peer tenant of the application, defaults to AZURE_TENANT_ID",
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+				"client_id": {
+					Description: "Application (client) ID, defaults to the default Azure credentials",
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+				"client_secret": {
+					Description: "Application client secret, defaults to AZURE_CLIENT_SECRET",
+					Optional:    true,
+					Sensitive:   true,
+					Type:        schema.TypeString,
+				},
+				"auxiliary_tenant_id": {
+					Description: "Aiven tenant where the application is also registered, for peering across tenants",
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+				"auxiliary_access_token": {
+					Description: "Azure Resource Manager access token of the Aiven tenant",
+					Optional:    true,
+					Sensitive:   true,
+					Type:        schema.TypeString,
+				},
+				"peering_name": {
+					Description: "Name of the virtual network peering",
+					Optional:    true,
+					Default:     "aiven",
+					Type:        schema.TypeString,
+				},
+				"endpoint": {
+					Description: "Custom Azure Resource Manager endpoint",
+					Optional:    true,
+					Type:        schema.TypeString,
+				},
+			},
+		},
+	},
+}
+
+// vpcPeeringConnectionAcceptor builds a cloud specific acceptor out of `accept_on_peer`
+// block, nil is returned if the block is not set
+func vpcPeeringConnectionAcceptor(d *schema.ResourceData) (peering.Acceptor, error) {
+	v, ok := d.GetOk("accept_on_peer")
+	if !ok {
+		return nil, nil
+	}
+
+	blocks := v.([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil, nil
+	}
+	block := blocks[0].(map[string]interface{})
+
+	var acceptors []peering.Acceptor
+	if c := firstBlock(block["aws"]); c != nil {
+		acceptors = append(acceptors, &peering.AWSAcceptor{
+			AccessKey:     c["access_key"].(string),
+			SecretKey:     c["secret_key"].(string),
+			SessionToken:  c["session_token"].(string),
+			RouteTableIDs: flattenToString(c["route_table_ids"].([]interface{})),
+			Endpoint:      c["endpoint"].(string),
+		})
+	}
+	if c := firstBlock(block["gcp"]); c != nil {
+		acceptors = append(acceptors, &peering.GCPAcceptor{
+			AccessToken: c["access_token"].(string),
+			Credentials: c["credentials"].(string),
+			Endpoint:    c["endpoint"].(string),
+		})
+	}
+	if c := firstBlock(block["azure"]); c != nil {
+		acceptors = append(acceptors, &peering.AzureAcceptor{
+			AccessToken:          c["access_token"].(string),
+			AuxiliaryAccessToken: c["auxiliary_access_token"].(string),
+			TenantID:             c["tenant_id"].(string),
+			ClientID:             c["client_id"].(string),
+			ClientSecret:         c["client_secret"].(string),
+			AuxiliaryTenantID:    c["auxiliary_tenant_id"].(string),
+			PeeringName:          c["peering_name"].(string),
+			Endpoint:             c["endpoint"].(string),
+		})
+	}
+
+	if len(acceptors) != 1 {
+		return nil, fmt.Errorf("exactly one of `aws`, `gcp` and `azure` must be set in `accept_on_peer`")
+	}
+
+	return acceptors[0], nil
+}
+
+func firstBlock(v interface{}) map[string]interface{} {
+	l, ok := v.([]interface{})
+	if !ok || len(l) == 0 || l[0] == nil {
+		return nil
+	}
+
+	return l[0].(map[string]interface{})
+}
+
+// acceptVPCPeeringConnection accepts a VPC peering connection in PENDING_PEER state on the
+// peer side and waits until Aiven sees the connection as active
+func acceptVPCPeeringConnection(
+	ctx context.Context,
+	d *schema.ResourceData,
+	client *aiven.Client,
+	acceptor peering.Acceptor,
+	pc *aiven.VPCPeeringConnection,
+	projectName, vpcID string,
+	timeout time.Duration,
+) (*aiven.VPCPeeringConnection, error) {
+	vpc, err := client.VPCs.Get(projectName, vpcID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get project VPC: %w", err)
+	}
+
+	req := peering.Request{
+		PeerCloudAccount:  pc.PeerCloudAccount,
+		PeerVPC:           pc.PeerVPC,
+		PeerRegion:        cloudRegion(vpc.CloudName),
+		PeerResourceGroup: pc.PeerResourceGroup,
+		AivenNetworkCIDR:  vpc.NetworkCIDR,
+	}
+	if pc.PeerRegion != nil && *pc.PeerRegion != "" {
+		req.PeerRegion = cloudRegion(*pc.PeerRegion)
+	}
+	if v, ok := d.GetOk("peer_resource_group"); ok && req.PeerResourceGroup == "" {
+		req.PeerResourceGroup = v.(string)
+	}
+	if pc.StateInfo != nil {
+		req.StateInfo = *pc.StateInfo
+	}
+
+	if err := acceptor.Accept(ctx, req); err != nil {
+		return nil, fmt.Errorf("cannot accept VPC peering connection on the peer side: %w", err)
+	}
+
+	peerCloudAccount, peerVPC, peerRegion, peerResourceGroup := pc.PeerCloudAccount, pc.PeerVPC, pc.PeerRegion, pc.PeerResourceGroup
+	stateChangeConf := &resource.StateChangeConf{
+		Pending: []string{"PENDING_PEER", "APPROVED"},
+		Target: []string{
+			"ACTIVE",
+			"REJECTED_BY_PEER",
+			"INVALID_SPECIFICATION",
+			"DELETING",
+			"DELETED",
+			"DELETED_BY_PEER",
+		},
+		Refresh: func() (interface{}, string, error) {
+			pc, err := client.VPCPeeringConnections.GetVPCPeeringWithResourceGroup(
+				projectName,
+				vpcID,
+				peerCloudAccount,
+				peerVPC,
+				peerRegion,
+				peerResourceGroup,
+			)
+			if err != nil {
+				return nil, "", err
+			}
+			return pc, pc.State, nil
+		},
+		Delay:      10 * time.Second,
+		Timeout:    timeout,
+		MinTimeout: 2 * time.Second,
+	}
+
+	res, err := stateChangeConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for VPC peering connection to become active: %w", err)
+	}
+
+	return res.(*aiven.VPCPeeringConnection), nil
+}
+
+// cloudRegion strips the cloud prefix from Aiven cloud name, e.g. `aws-eu-west-1` becomes `eu-west-1`
+func cloudRegion(cloudName string) string {
+	for _, prefix := range []string{"aws-", "google-", "azure-", "do-", "upcloud-"} {
+		if strings.HasPrefix(cloudName, prefix) {
+			return strings.TrimPrefix(cloudName, prefix)
+		}
+	}
+
+	return cloudName
+}
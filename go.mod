@@ -3,12 +3,16 @@ module github.com/aiven/terraform-provider-aiven
 go 1.16
 
 require (
+	github.com/Azure/azure-sdk-for-go/sdk/azcore v1.11.1
+	github.com/Azure/azure-sdk-for-go/sdk/azidentity v1.7.0
 	github.com/aiven/aiven-go-client v1.6.1
-	github.com/aws/aws-sdk-go v1.30.12 // indirect
-	github.com/google/go-cmp v0.5.6
+	github.com/aws/aws-sdk-go v1.30.12
+	github.com/go-sql-driver/mysql v1.6.0
+	github.com/google/go-cmp v0.6.0
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.7.0
+	github.com/lib/pq v1.10.2
 	github.com/mitchellh/mapstructure v1.3.2 // indirect
-	github.com/stretchr/testify v1.7.0
-	golang.org/x/sync v0.0.0-20200625203802-6e8e738ad208
-	gopkg.in/yaml.v3 v3.0.0-20200615113413-eeeca48fe776 // indirect
+	github.com/stretchr/testify v1.9.0
+	golang.org/x/oauth2 v0.0.0-20200107190931-bf48bf16ab8d
+	golang.org/x/sync v0.7.0
 )
@@ -0,0 +1,101 @@
+package peering
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/ec2"
+)
+
+// AWSAcceptor accepts AWS VPC peering connections and adds routes to the Aiven
+// project VPC network to the given route tables
+type AWSAcceptor struct {
+	AccessKey     string
+	SecretKey     string
+	SessionToken  string
+	RouteTableIDs []string
+	// Endpoint overrides EC2 API endpoint, it is empty by default
+	Endpoint string
+}
+
+const awsPeeringConnectionActive = "active"
+
+// Accept accepts AWS VPC peering connection
+func (a *AWSAcceptor) Accept(ctx context.Context, req Request) error {
+	peeringID, err := stateInfoString(req, "aws_vpc_peering_connection_id")
+	if err != nil {
+		return err
+	}
+
+	if req.PeerRegion == "" {
+		return fmt.Errorf("cannot accept AWS VPC peering connection %s without a region", peeringID)
+	}
+
+	cfg := aws.NewConfig().
+		WithRegion(req.PeerRegion).
+		WithCredentials(credentials.NewStaticCredentials(a.AccessKey, a.SecretKey, a.SessionToken))
+	if a.Endpoint != "" {
+		cfg = cfg.WithEndpoint(a.Endpoint)
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return fmt.Errorf("cannot create AWS session: %w", err)
+	}
+	svc := ec2.New(sess)
+
+	status, err := a.peeringStatus(ctx, svc, peeringID)
+	if err != nil {
+		return err
+	}
+
+	if status != awsPeeringConnectionActive {
+		log.Printf("[DEBUG] accepting AWS VPC peering connection %s in %s status", peeringID, status)
+		if _, err := svc.AcceptVpcPeeringConnectionWithContext(ctx, &ec2.AcceptVpcPeeringConnectionInput{
+			VpcPeeringConnectionId: aws.String(peeringID),
+		}); err != nil {
+			return fmt.Errorf("cannot accept AWS VPC peering connection %s: %w", peeringID, err)
+		}
+	}
+
+	for _, rt := range a.RouteTableIDs {
+		_, err := svc.CreateRouteWithContext(ctx, &ec2.CreateRouteInput{
+			DestinationCidrBlock:   aws.String(req.AivenNetworkCIDR),
+			RouteTableId:           aws.String(rt),
+			VpcPeeringConnectionId: aws.String(peeringID),
+		})
+		if err != nil {
+			if aerr, ok := err.(awserr.Error); ok && aerr.Code() == "RouteAlreadyExists" {
+				continue
+			}
+			return fmt.Errorf("cannot create a route to %s in the route table %s: %w", req.AivenNetworkCIDR, rt, err)
+		}
+	}
+
+	return nil
+}
+
+func (a *AWSAcceptor) peeringStatus(ctx context.Context, svc *ec2.EC2, peeringID string) (string, error) {
+	out, err := svc.DescribeVpcPeeringConnectionsWithContext(ctx, &ec2.DescribeVpcPeeringConnectionsInput{
+		VpcPeeringConnectionIds: []*string{aws.String(peeringID)},
+	})
+	if err != nil {
+		return "", fmt.Errorf("cannot describe AWS VPC peering connection %s: %w", peeringID, err)
+	}
+
+	if len(out.VpcPeeringConnections) == 0 {
+		return "", fmt.Errorf("AWS VPC peering connection %s is not visible to the peer account", peeringID)
+	}
+
+	pc := out.VpcPeeringConnections[0]
+	if pc.Status == nil || pc.Status.Code == nil {
+		return "", nil
+	}
+
+	return *pc.Status.Code, nil
+}
@@ -0,0 +1,114 @@
+package peering
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeEC2 is a minimal fake of EC2 Query API that serves VPC peering connection calls
+type fakeEC2 struct {
+	status        string
+	accepted      []string
+	routes        map[string]string
+	existingRoute string
+}
+
+func (f *fakeEC2) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/xml")
+	switch r.Form.Get("Action") {
+	case "DescribeVpcPeeringConnections":
+		fmt.Fprintf(w, `<DescribeVpcPeeringConnectionsResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <requestId>1</requestId>
+  <vpcPeeringConnectionSet>
+    <item>
+      <vpcPeeringConnectionId>%s</vpcPeeringConnectionId>
+      <status><code>%s</code></status>
+    </item>
+  </vpcPeeringConnectionSet>
+</DescribeVpcPeeringConnectionsResponse>`, r.Form.Get("VpcPeeringConnectionId.1"), f.status)
+	case "AcceptVpcPeeringConnection":
+		id := r.Form.Get("VpcPeeringConnectionId")
+		f.accepted = append(f.accepted, id)
+		f.status = awsPeeringConnectionActive
+		fmt.Fprintf(w, `<AcceptVpcPeeringConnectionResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <requestId>2</requestId>
+  <vpcPeeringConnection>
+    <vpcPeeringConnectionId>%s</vpcPeeringConnectionId>
+    <status><code>provisioning</code></status>
+  </vpcPeeringConnection>
+</AcceptVpcPeeringConnectionResponse>`, id)
+	case "CreateRoute":
+		rt := r.Form.Get("RouteTableId")
+		if rt == f.existingRoute {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `<Response><Errors><Error><Code>RouteAlreadyExists</Code>`+
+				`<Message>route exists</Message></Error></Errors><RequestID>3</RequestID></Response>`)
+			return
+		}
+		f.routes[rt] = r.Form.Get("DestinationCidrBlock") + " via " + r.Form.Get("VpcPeeringConnectionId")
+		fmt.Fprint(w, `<CreateRouteResponse xmlns="http://ec2.amazonaws.com/doc/2016-11-15/">
+  <requestId>4</requestId>
+  <return>true</return>
+</CreateRouteResponse>`)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+	}
+}
+
+func TestAWSAcceptor_Accept(t *testing.T) {
+	fake := &fakeEC2{status: "pending-acceptance", routes: map[string]string{}, existingRoute: "rtb-2"}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	a := &AWSAcceptor{
+		AccessKey:     "AKID",
+		SecretKey:     "SECRET",
+		RouteTableIDs: []string{"rtb-1", "rtb-2"},
+		Endpoint:      srv.URL,
+	}
+
+	req := Request{
+		PeerCloudAccount: "123456789012",
+		PeerVPC:          "vpc-1",
+		PeerRegion:       "eu-west-1",
+		AivenNetworkCIDR: "10.0.0.0/24",
+		StateInfo:        map[string]interface{}{"aws_vpc_peering_connection_id": "pcx-1"},
+	}
+
+	if err := a.Accept(context.Background(), req); err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+
+	if len(fake.accepted) != 1 || fake.accepted[0] != "pcx-1" {
+		t.Errorf("expected pcx-1 to be accepted once, got %v", fake.accepted)
+	}
+
+	if got := fake.routes["rtb-1"]; got != "10.0.0.0/24 via pcx-1" {
+		t.Errorf("unexpected route in rtb-1: %q", got)
+	}
+
+	// The connection is active now, accepting again only makes sure the routes exist
+	if err := a.Accept(context.Background(), req); err != nil {
+		t.Fatalf("second Accept() error = %v", err)
+	}
+
+	if len(fake.accepted) != 1 {
+		t.Errorf("active connection must not be accepted again, got %v", fake.accepted)
+	}
+}
+
+func TestAWSAcceptor_AcceptWithoutPeeringID(t *testing.T) {
+	a := &AWSAcceptor{}
+	err := a.Accept(context.Background(), Request{PeerRegion: "eu-west-1", StateInfo: map[string]interface{}{}})
+	if err == nil {
+		t.Fatal("expected an error when state info has no peering connection id")
+	}
+}
@@ -0,0 +1,117 @@
+package peering
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// AzureAcceptor creates the peer side of an Azure virtual network peering
+type AzureAcceptor struct {
+	// AccessToken is a short lived Azure Resource Manager access token of the peer tenant,
+	// it takes precedence over the client credentials
+	AccessToken string
+	// AuxiliaryAccessToken is an Azure Resource Manager access token of the Aiven tenant,
+	// it is required when the peered virtual networks are in different tenants
+	AuxiliaryAccessToken string
+	// TenantID, ClientID and ClientSecret are the credentials of an Azure AD application,
+	// without them the default Azure credentials of the host are used
+	TenantID     string
+	ClientID     string
+	ClientSecret string
+	// AuxiliaryTenantID is the Aiven tenant, the application must be registered there
+	// when the peered virtual networks are in different tenants
+	AuxiliaryTenantID string
+	// Authority overrides Azure AD endpoint, it is empty by default
+	Authority string
+	// PeeringName is a name of the virtual network peering, `aiven` by default
+	PeeringName string
+	// Endpoint overrides Azure Resource Manager endpoint, it is empty by default
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+const (
+	azureResourceManagerEndpoint = "https://management.azure.com"
+	azureNetworkAPIVersion       = "2020-11-01"
+)
+
+type azureVirtualNetworkPeering struct {
+	Properties azureVirtualNetworkPeeringProperties `json:"properties"`
+}
+
+type azureVirtualNetworkPeeringProperties struct {
+	RemoteVirtualNetwork      azureSubResource `json:"remoteVirtualNetwork"`
+	AllowVirtualNetworkAccess bool             `json:"allowVirtualNetworkAccess"`
+	AllowForwardedTraffic     bool             `json:"allowForwardedTraffic"`
+	UseRemoteGateways         bool             `json:"useRemoteGateways"`
+	PeeringState              string           `json:"peeringState,omitempty"`
+}
+
+type azureSubResource struct {
+	ID string `json:"id"`
+}
+
+// Accept peers user virtual network with the Aiven virtual network
+func (a *AzureAcceptor) Accept(ctx context.Context, req Request) error {
+	remoteNetworkID, err := stateInfoString(req, "to_network_id")
+	if err != nil {
+		return err
+	}
+
+	if req.PeerResourceGroup == "" {
+		return fmt.Errorf("cannot accept Azure virtual network peering without a resource group")
+	}
+
+	endpoint := a.Endpoint
+	if endpoint == "" {
+		endpoint = azureResourceManagerEndpoint
+	}
+
+	name := a.PeeringName
+	if name == "" {
+		name = "aiven"
+	}
+
+	url := fmt.Sprintf(
+		"%s/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s/virtualNetworkPeerings/%s?api-version=%s",
+		endpoint, req.PeerCloudAccount, req.PeerResourceGroup, req.PeerVPC, name, azureNetworkAPIVersion)
+
+	credentials := azureClientCredentials{
+		ClientID:     a.ClientID,
+		ClientSecret: a.ClientSecret,
+		Authority:    a.Authority,
+	}
+
+	token := a.AccessToken
+	if token == "" {
+		if token, err = azureToken(ctx, a.HTTPClient, credentials, a.TenantID); err != nil {
+			return fmt.Errorf("cannot get Azure access token: %w", err)
+		}
+	}
+
+	auxiliaryToken := a.AuxiliaryAccessToken
+	if auxiliaryToken == "" && a.AuxiliaryTenantID != "" {
+		if auxiliaryToken, err = azureToken(ctx, a.HTTPClient, credentials, a.AuxiliaryTenantID); err != nil {
+			return fmt.Errorf("cannot get Azure access token of the Aiven tenant: %w", err)
+		}
+	}
+
+	var headers map[string]string
+	if auxiliaryToken != "" {
+		headers = map[string]string{"x-ms-authorization-auxiliary": "Bearer " + auxiliaryToken}
+	}
+
+	// PUT is idempotent, an existing peering with the same name is updated
+	if err := doJSONRequest(ctx, a.HTTPClient, http.MethodPut, url, token, headers,
+		azureVirtualNetworkPeering{
+			Properties: azureVirtualNetworkPeeringProperties{
+				RemoteVirtualNetwork:      azureSubResource{ID: remoteNetworkID},
+				AllowVirtualNetworkAccess: true,
+			},
+		}, nil); err != nil {
+		return fmt.Errorf("cannot create Azure virtual network peering to %s: %w", remoteNetworkID, err)
+	}
+
+	return nil
+}
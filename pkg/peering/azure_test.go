@@ -0,0 +1,80 @@
+package peering
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAzureAcceptor_Accept(t *testing.T) {
+	var (
+		got       azureVirtualNetworkPeering
+		path      string
+		version   string
+		auxiliary string
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		path = r.URL.Path
+		version = r.URL.Query().Get("api-version")
+		auxiliary = r.Header.Get("x-ms-authorization-auxiliary")
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		got.Properties.PeeringState = "Initiated"
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(got)
+	}))
+	defer srv.Close()
+
+	a := &AzureAcceptor{AccessToken: "token", AuxiliaryAccessToken: "aiven-token", Endpoint: srv.URL}
+	err := a.Accept(context.Background(), Request{
+		PeerCloudAccount:  "subscription",
+		PeerVPC:           "my-vnet",
+		PeerResourceGroup: "my-rg",
+		StateInfo: map[string]interface{}{
+			"to_network_id": "/subscriptions/aiven/resourceGroups/aiven/providers/Microsoft.Network/virtualNetworks/aiven",
+			"to_tenant_id":  "aiven-tenant",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+
+	expectedPath := "/subscriptions/subscription/resourceGroups/my-rg/providers/Microsoft.Network/virtualNetworks/my-vnet/virtualNetworkPeerings/aiven"
+	if path != expectedPath {
+		t.Errorf("unexpected path %s", path)
+	}
+
+	if version != azureNetworkAPIVersion {
+		t.Errorf("unexpected api version %s", version)
+	}
+
+	if auxiliary != "Bearer aiven-token" {
+		t.Errorf("unexpected auxiliary authorization header %s", auxiliary)
+	}
+
+	if !got.Properties.AllowVirtualNetworkAccess ||
+		got.Properties.RemoteVirtualNetwork.ID != "/subscriptions/aiven/resourceGroups/aiven/providers/Microsoft.Network/virtualNetworks/aiven" {
+		t.Errorf("unexpected peering %+v", got)
+	}
+}
+
+func TestAzureAcceptor_AcceptWithoutResourceGroup(t *testing.T) {
+	a := &AzureAcceptor{AccessToken: "token"}
+	err := a.Accept(context.Background(), Request{
+		StateInfo: map[string]interface{}{"to_network_id": "id"},
+	})
+	if err == nil {
+		t.Fatal("expected an error without a resource group")
+	}
+}
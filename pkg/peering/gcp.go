@@ -0,0 +1,109 @@
+package peering
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// GCPAcceptor creates the peer side of a Google Cloud VPC network peering. Google Cloud
+// exchanges the subnet routes of peered networks automatically.
+type GCPAcceptor struct {
+	// AccessToken is a short lived OAuth 2.0 access token, it takes precedence over Credentials
+	AccessToken string
+	// Credentials is a service account key or a path to it, without it the Google
+	// application default credentials are used
+	Credentials string
+	// Endpoint overrides Compute Engine API endpoint, it is empty by default
+	Endpoint   string
+	HTTPClient *http.Client
+}
+
+const gcpComputeEndpoint = "https://compute.googleapis.com/compute/v1"
+
+var gcpInvalidNameChars = regexp.MustCompile("[^a-z0-9-]+")
+
+type gcpNetwork struct {
+	Peerings []struct {
+		Name    string `json:"name"`
+		Network string `json:"network"`
+		State   string `json:"state"`
+	} `json:"peerings"`
+}
+
+type gcpAddPeeringRequest struct {
+	NetworkPeering gcpNetworkPeering `json:"networkPeering"`
+}
+
+type gcpNetworkPeering struct {
+	Name                 string `json:"name"`
+	Network              string `json:"network"`
+	ExchangeSubnetRoutes bool   `json:"exchangeSubnetRoutes"`
+}
+
+// Accept peers user VPC network with the Aiven VPC network
+func (a *GCPAcceptor) Accept(ctx context.Context, req Request) error {
+	toProject, err := stateInfoString(req, "to_project_id")
+	if err != nil {
+		return err
+	}
+
+	toNetwork, err := stateInfoString(req, "to_vpc_network")
+	if err != nil {
+		return err
+	}
+
+	token := a.AccessToken
+	if token == "" {
+		if token, err = gcpToken(ctx, a.HTTPClient, a.Credentials); err != nil {
+			return fmt.Errorf("cannot get GCP access token: %w", err)
+		}
+	}
+
+	endpoint := a.Endpoint
+	if endpoint == "" {
+		endpoint = gcpComputeEndpoint
+	}
+
+	networkURL := fmt.Sprintf("%s/projects/%s/global/networks/%s", endpoint, req.PeerCloudAccount, req.PeerVPC)
+	remoteNetwork := fmt.Sprintf("projects/%s/global/networks/%s", toProject, toNetwork)
+
+	var network gcpNetwork
+	if err := doJSONRequest(ctx, a.HTTPClient, http.MethodGet, networkURL, token, nil, nil, &network); err != nil {
+		return fmt.Errorf("cannot get GCP VPC network %s: %w", req.PeerVPC, err)
+	}
+
+	for _, p := range network.Peerings {
+		if strings.HasSuffix(p.Network, remoteNetwork) {
+			log.Printf("[DEBUG] GCP VPC network %s is already peered with %s", req.PeerVPC, remoteNetwork)
+			return nil
+		}
+	}
+
+	if err := doJSONRequest(ctx, a.HTTPClient, http.MethodPost, networkURL+"/addPeering", token, nil,
+		gcpAddPeeringRequest{
+			NetworkPeering: gcpNetworkPeering{
+				Name:                 gcpPeeringName(toNetwork),
+				Network:              remoteNetwork,
+				ExchangeSubnetRoutes: true,
+			},
+		}, nil); err != nil {
+		return fmt.Errorf("cannot add GCP VPC network peering to %s: %w", remoteNetwork, err)
+	}
+
+	return nil
+}
+
+// gcpPeeringName builds a peering name that matches GCP resource name requirements,
+// lowercase letters, digits and dashes, at most 63 characters
+func gcpPeeringName(network string) string {
+	name := "aiven-" + strings.Trim(gcpInvalidNameChars.ReplaceAllString(strings.ToLower(network), "-"), "-")
+	if len(name) > 63 {
+		name = name[:63]
+	}
+
+	return strings.TrimRight(name, "-")
+}
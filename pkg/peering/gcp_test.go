@@ -0,0 +1,95 @@
+package peering
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// fakeCompute is a minimal fake of Compute Engine API networks endpoints
+type fakeCompute struct {
+	network gcpNetwork
+	added   []gcpNetworkPeering
+}
+
+func (f *fakeCompute) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/projects/my-project/global/networks/my-network":
+		_ = json.NewEncoder(w).Encode(f.network)
+	case r.Method == http.MethodPost && r.URL.Path == "/projects/my-project/global/networks/my-network/addPeering":
+		var req gcpAddPeeringRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.added = append(f.added, req.NetworkPeering)
+		f.network.Peerings = append(f.network.Peerings, struct {
+			Name    string `json:"name"`
+			Network string `json:"network"`
+			State   string `json:"state"`
+		}{req.NetworkPeering.Name, "https://www.googleapis.com/compute/v1/" + req.NetworkPeering.Network, "ACTIVE"})
+		_, _ = w.Write([]byte(`{"kind": "compute#operation", "status": "RUNNING"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestGCPAcceptor_Accept(t *testing.T) {
+	fake := &fakeCompute{}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	a := &GCPAcceptor{AccessToken: "token", Endpoint: srv.URL}
+	req := Request{
+		PeerCloudAccount: "my-project",
+		PeerVPC:          "my-network",
+		StateInfo: map[string]interface{}{
+			"to_project_id":  "aiven-project",
+			"to_vpc_network": "aiven_VPC_1234",
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := a.Accept(context.Background(), req); err != nil {
+			t.Fatalf("Accept() error = %v", err)
+		}
+	}
+
+	if len(fake.added) != 1 {
+		t.Fatalf("expected exactly one peering to be added, got %d", len(fake.added))
+	}
+
+	p := fake.added[0]
+	if p.Network != "projects/aiven-project/global/networks/aiven_VPC_1234" || !p.ExchangeSubnetRoutes {
+		t.Errorf("unexpected peering %+v", p)
+	}
+
+	if p.Name != "aiven-aiven-vpc-1234" {
+		t.Errorf("unexpected peering name %s", p.Name)
+	}
+}
+
+func TestGCPAcceptor_AcceptUnauthorized(t *testing.T) {
+	srv := httptest.NewServer(&fakeCompute{})
+	defer srv.Close()
+
+	a := &GCPAcceptor{AccessToken: "wrong", Endpoint: srv.URL}
+	err := a.Accept(context.Background(), Request{
+		PeerCloudAccount: "my-project",
+		PeerVPC:          "my-network",
+		StateInfo: map[string]interface{}{
+			"to_project_id":  "aiven-project",
+			"to_vpc_network": "aiven-vpc",
+		},
+	})
+	if err == nil {
+		t.Fatal("expected an error for an unauthorized request")
+	}
+}
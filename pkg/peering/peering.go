@@ -0,0 +1,111 @@
+// Package peering completes VPC peering connections on the peer side, in the cloud account
+// of the user, after Aiven has created its side of the connection.
+package peering
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+)
+
+// Request describes a VPC peering connection that is waiting for the peer to accept it
+type Request struct {
+	// PeerCloudAccount is AWS account ID, GCP project ID or Azure subscription ID of the peered VPC
+	PeerCloudAccount string
+	// PeerVPC is AWS VPC ID, GCP VPC network name or Azure VNet name of the peered VPC
+	PeerVPC string
+	// PeerRegion is a cloud region of the peered VPC without the cloud prefix, e.g. `eu-west-1`
+	PeerRegion string
+	// PeerResourceGroup is Azure resource group name of the peered VPC
+	PeerResourceGroup string
+	// AivenNetworkCIDR is a network CIDR of the Aiven project VPC
+	AivenNetworkCIDR string
+	// StateInfo is a state info of the VPC peering connection in PENDING_PEER state
+	StateInfo map[string]interface{}
+}
+
+// Acceptor accepts a VPC peering connection in the cloud account of the peer
+// and sets up routes towards the Aiven project VPC
+type Acceptor interface {
+	Accept(ctx context.Context, req Request) error
+}
+
+// stateInfoString gets a string value from the state info
+func stateInfoString(req Request, key string) (string, error) {
+	v, ok := req.StateInfo[key]
+	if !ok {
+		return "", fmt.Errorf("state info of the VPC peering connection has no `%s`", key)
+	}
+
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return "", fmt.Errorf("state info of the VPC peering connection has invalid `%s`: %v", key, v)
+	}
+
+	return s, nil
+}
+
+// Error is returned when a cloud REST API responds with an unexpected status
+type Error struct {
+	Status int
+	Body   string
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("%d: %s", e.Status, e.Body)
+}
+
+// doJSONRequest sends a JSON request with a bearer token and decodes a JSON response into out
+func doJSONRequest(
+	ctx context.Context,
+	client *http.Client,
+	method, url, token string,
+	headers map[string]string,
+	in, out interface{},
+) error {
+	var body []byte
+	if in != nil {
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	rsp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	b, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return err
+	}
+
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return Error{Status: rsp.StatusCode, Body: string(b)}
+	}
+
+	if out == nil || len(b) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(b, out)
+}
@@ -0,0 +1,134 @@
+package peering
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+const (
+	gcpComputeScope          = "https://www.googleapis.com/auth/compute"
+	azureResourceManagerHost = "https://management.azure.com/"
+)
+
+// gcpToken returns an OAuth 2.0 access token of Compute Engine API; credentials may be a
+// service account key or authorized user credentials, without them the application default
+// credentials are used, which include the GCE metadata server and GKE workload identity
+func gcpToken(ctx context.Context, client *http.Client, credentials string) (string, error) {
+	if client != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, client)
+	}
+
+	var c *google.Credentials
+	if credentials == "" {
+		var err error
+		if c, err = google.FindDefaultCredentials(ctx, gcpComputeScope); err != nil {
+			return "", fmt.Errorf("no GCP credentials are set and the default credentials cannot be used: %w", err)
+		}
+	} else {
+		keyJSON, err := readCredentials(credentials)
+		if err != nil {
+			return "", err
+		}
+
+		if c, err = google.CredentialsFromJSON(ctx, keyJSON, gcpComputeScope); err != nil {
+			return "", fmt.Errorf("cannot parse GCP credentials: %w", err)
+		}
+	}
+
+	token, err := c.TokenSource.Token()
+	if err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
+// readCredentials accepts either credentials themselves or a path to a file holding them
+func readCredentials(credentials string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(credentials), "{") {
+		return []byte(credentials), nil
+	}
+
+	b, err := ioutil.ReadFile(credentials)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read credentials file: %w", err)
+	}
+
+	return b, nil
+}
+
+// azureClientCredentials are the credentials of an Azure AD application, empty client ID
+// selects the default credentials of the host Terraform runs on
+type azureClientCredentials struct {
+	ClientID     string
+	ClientSecret string
+	// Authority overrides Azure AD endpoint, it is empty by default
+	Authority string
+}
+
+// azureToken returns an Azure Resource Manager access token of the tenant; without client
+// credentials the default credentials are used, which are taken from the AZURE_*
+// environment variables, workload identity, the managed identity of the host or Azure CLI
+func azureToken(ctx context.Context, client *http.Client, c azureClientCredentials, tenantID string) (string, error) {
+	var options azcore.ClientOptions
+	if c.Authority != "" {
+		options.Cloud = cloud.Configuration{ActiveDirectoryAuthorityHost: c.Authority}
+	}
+	if client != nil {
+		options.Transport = client
+	}
+
+	var credential azcore.TokenCredential
+	if c.ClientID != "" && c.ClientSecret != "" {
+		if tenantID == "" {
+			tenantID = os.Getenv("AZURE_TENANT_ID")
+		}
+		if tenantID == "" {
+			return "", fmt.Errorf("Azure client credentials require a tenant ID")
+		}
+
+		var err error
+		credential, err = azidentity.NewClientSecretCredential(tenantID, c.ClientID, c.ClientSecret,
+			&azidentity.ClientSecretCredentialOptions{ClientOptions: options, DisableInstanceDiscovery: c.Authority != ""})
+		if err != nil {
+			return "", err
+		}
+	} else {
+		var err error
+		o := &azidentity.DefaultAzureCredentialOptions{
+			ClientOptions:            options,
+			TenantID:                 tenantID,
+			DisableInstanceDiscovery: c.Authority != "",
+		}
+		if tenantID != "" {
+			// the environment credentials are of the tenant in AZURE_TENANT_ID
+			o.AdditionallyAllowedTenants = []string{tenantID}
+		}
+
+		credential, err = azidentity.NewDefaultAzureCredential(o)
+		if err != nil {
+			return "", fmt.Errorf("no Azure client credentials are set and the default credentials cannot be used: %w", err)
+		}
+	}
+
+	token, err := credential.GetToken(ctx, policy.TokenRequestOptions{
+		Scopes:   []string{azureResourceManagerHost + ".default"},
+		TenantID: tenantID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token.Token, nil
+}
@@ -0,0 +1,98 @@
+package peering
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGCPToken(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.Form.Get("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if parts := strings.Split(r.Form.Get("assertion"), "."); len(parts) != 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token": "gcp-token", "token_type": "Bearer", "expires_in": 3600}`))
+	}))
+	defer srv.Close()
+
+	key, _ := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"client_email":   "peering@my-project.iam.gserviceaccount.com",
+		"private_key_id": "key",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":      srv.URL,
+	})
+
+	token, err := gcpToken(context.Background(), srv.Client(), string(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "gcp-token" {
+		t.Errorf("unexpected token %s", token)
+	}
+
+	if _, err := gcpToken(context.Background(), srv.Client(), `{"type": "unknown"}`); err == nil {
+		t.Error("expected an error for unsupported credentials")
+	}
+}
+
+func TestAzureToken(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my-tenant/v2.0/.well-known/openid-configuration":
+			_, _ = w.Write([]byte(`{
+				"token_endpoint": "` + srv.URL + `/my-tenant/oauth2/v2.0/token",
+				"authorization_endpoint": "` + srv.URL + `/my-tenant/oauth2/v2.0/authorize",
+				"issuer": "` + srv.URL + `/my-tenant/v2.0"
+			}`))
+		case "/my-tenant/oauth2/v2.0/token":
+			if err := r.ParseForm(); err != nil || r.Form.Get("client_secret") != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error": "invalid_client"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"access_token": "azure-token", "token_type": "Bearer", "expires_in": 3600}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	c := azureClientCredentials{ClientID: "app", ClientSecret: "secret", Authority: srv.URL}
+	token, err := azureToken(context.Background(), srv.Client(), c, "my-tenant")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "azure-token" {
+		t.Errorf("unexpected token %s", token)
+	}
+
+	c.ClientSecret = "wrong"
+	if _, err := azureToken(context.Background(), srv.Client(), c, "my-tenant"); err == nil {
+		t.Error("expected an error for invalid credentials")
+	}
+}